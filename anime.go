@@ -0,0 +1,247 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/f4n4t/go-release/pkg/progress"
+)
+
+// ErrCRCValidationFailed indicates that the CRC embedded in the name of a file does not match its content.
+var ErrCRCValidationFailed = errors.New("crc check failed")
+
+// AnimeName holds the tokens of an anime or fansub name like "[Group] Title - 12 [1080p][ABCD1234].mkv" or the
+// batch "[Group] Title (01-24) [1080p]". The group is in front of the name in brackets, the other tags follow the
+// title in brackets or parentheses.
+type AnimeName struct {
+	// Group is the release group without the brackets.
+	Group Token `json:"group"`
+	// Title is the title in front of the episode and the tags, underscores are replaced with spaces.
+	Title Token `json:"title"`
+	// Episode is the absolute episode number, the first one of a batch, e.g. "12" or "01" for (01-24).
+	Episode Token `json:"episode"`
+	// LastEpisode is the last episode number of a batch, e.g. "24" for (01-24).
+	LastEpisode Token `json:"last_episode"`
+	// Version is the version of a re-released episode, e.g. "2" for 12v2.
+	Version Token `json:"version"`
+	// Resolution is the resolution tag, e.g. "1080p" or "1920x1080".
+	Resolution Token `json:"resolution"`
+	// CRC is the CRC32 of the file as hex, e.g. "ABCD1234".
+	CRC Token `json:"crc"`
+}
+
+// animeRegexes holds the patterns of ParseAnimeName.
+var animeRegexes = struct {
+	group, episode, tag, batch, resolution, crc, extension, badChars *regexp.Regexp
+}{
+	group:      regexp.MustCompile(`^\[([^\[\]]+)\][ _.]*`),
+	episode:    regexp.MustCompile(`(?i)(?:^|[ _])-[ _](\d{2,4})(?:v(\d))?(?:[ _]?[-~][ _]?(\d{2,4})(?:v\d)?)?(?:[ _.\[(]|$)`),
+	tag:        regexp.MustCompile(`\[([^\[\]]*)\]|\(([^()]*)\)`),
+	batch:      regexp.MustCompile(`^[ _]*(\d{2,4})[ _]?[-~][ _]?(\d{2,4})[ _]*$`),
+	resolution: regexp.MustCompile(`(?i)(?:^|[^0-9a-z])((?:480|576|720|1080|2160)[pi]|\d{3,4}x\d{3,4})(?:[^0-9a-z]|$)`),
+	crc:        regexp.MustCompile(`^[0-9A-Fa-f]{8}$`),
+	extension:  regexp.MustCompile(`^\.[0-9A-Za-z]{2,4}$`),
+	// badChars replaces Regexes.BadChars for anime releases, fansub names contain spaces and more punctuation.
+	badChars: regexp.MustCompile(`(?i)[^a-z0-9()\[\].\-_+ ~!&',]`),
+}
+
+// ParseAnimeName parses the tokens of an anime or fansub name, the name can be a release or a file name.
+// It returns false if the name has no group in brackets in front of the title.
+func ParseAnimeName(name string) (AnimeName, bool) {
+	var an AnimeName
+
+	if ext := path.Ext(name); animeRegexes.extension.MatchString(ext) {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	m := animeRegexes.group.FindStringSubmatchIndex(name)
+	if m == nil {
+		return AnimeName{}, false
+	}
+
+	an.Group = tokenFromIndex(name, m[2], m[3])
+
+	// the title ends with the episode or the first tag
+	titleStart, titleEnd := m[1], len(name)
+
+	if m := animeRegexes.episode.FindStringSubmatchIndex(name[titleStart:]); m != nil {
+		titleEnd = titleStart + m[0]
+		an.Episode = tokenFromIndex(name, titleStart+m[2], titleStart+m[3])
+		an.Version = tokenFromIndex(name, offsetIndex(titleStart, m[4]), offsetIndex(titleStart, m[5]))
+		an.LastEpisode = tokenFromIndex(name, offsetIndex(titleStart, m[6]), offsetIndex(titleStart, m[7]))
+	}
+
+	for _, tag := range animeRegexes.tag.FindAllStringSubmatchIndex(name[titleStart:], -1) {
+		start, end := titleStart+tag[2], titleStart+tag[3]
+		if tag[2] < 0 {
+			start, end = titleStart+tag[4], titleStart+tag[5]
+		}
+
+		titleEnd = min(titleEnd, titleStart+tag[0])
+		value := name[start:end]
+
+		switch {
+		case animeRegexes.crc.MatchString(value):
+			an.CRC = tokenFromIndex(name, start, end)
+
+		case !an.Episode.Found() && animeRegexes.batch.MatchString(value):
+			b := animeRegexes.batch.FindStringSubmatchIndex(value)
+			an.Episode = tokenFromIndex(name, start+b[2], start+b[3])
+			an.LastEpisode = tokenFromIndex(name, start+b[4], start+b[5])
+
+		case !an.Resolution.Found():
+			if r := animeRegexes.resolution.FindStringSubmatchIndex(value); r != nil {
+				an.Resolution = tokenFromIndex(name, start+r[2], start+r[3])
+			}
+		}
+	}
+
+	title := strings.TrimRight(name[titleStart:titleEnd], " _.-")
+	if title == "" {
+		return AnimeName{}, false
+	}
+
+	an.Title = Token{
+		Value: strings.Join(strings.FieldsFunc(title, func(r rune) bool { return r == '_' || r == ' ' }), " "),
+		Start: titleStart,
+		End:   titleStart + len(title),
+	}
+
+	return an, true
+}
+
+// offsetIndex adds the offset to a submatch index, negative indices (no match) stay negative.
+func offsetIndex(offset, index int) int {
+	if index < 0 {
+		return index
+	}
+	return offset + index
+}
+
+// IsBatch reports whether the name is a batch with more than one episode, e.g. (01-24).
+func (an AnimeName) IsBatch() bool {
+	return an.LastEpisode.Found()
+}
+
+// CRC32 returns the CRC embedded in the name, false if the name has none.
+func (an AnimeName) CRC32() (uint32, bool) {
+	if !an.CRC.Found() {
+		return 0, false
+	}
+
+	crc, err := strconv.ParseUint(an.CRC.Value, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(crc), true
+}
+
+// episodeNumbers returns the absolute numbers of the episode or the batch.
+func (an AnimeName) episodeNumbers() []EpisodeNumber {
+	if !an.Episode.Found() {
+		return nil
+	}
+
+	start := an.Episode.Int()
+	end := start
+	if an.LastEpisode.Found() {
+		end = an.LastEpisode.Int()
+	}
+	if end < start || end-start > 2000 {
+		end = start
+	}
+
+	numbers := make([]EpisodeNumber, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		numbers = append(numbers, EpisodeNumber{AbsoluteNumber: number})
+	}
+
+	return numbers
+}
+
+// embeddedCRCFile is a media file with the CRC embedded in its name.
+type embeddedCRCFile struct {
+	path string
+	crc  uint32
+}
+
+// CheckEmbeddedCRC verifies the media files against the CRC embedded in their names, e.g. [ABCD1234] of fansub
+// releases without a sfv file. Files without a CRC in their name are skipped, on failure a *ReportError is returned
+// that matches ErrCRCValidationFailed and the errors of all findings.
+func (s *Service) CheckEmbeddedCRC(rel *Info, showProgress bool) error {
+	report, err := s.CheckEmbeddedCRCReport(rel, showProgress)
+	if err != nil {
+		return err
+	}
+
+	return report.err(ErrCRCValidationFailed)
+}
+
+// CheckEmbeddedCRCReport works like CheckEmbeddedCRC, but returns the CRC mismatches as report.
+// An error is only returned if the check can't continue, e.g. if the context is canceled.
+func (s *Service) CheckEmbeddedCRCReport(rel *Info, showProgress bool) (*Report, error) {
+	startTime := time.Now()
+
+	report := &Report{}
+
+	var (
+		files     []embeddedCRCFile
+		totalSize int64
+	)
+
+	for _, file := range rel.MediaFiles {
+		an, ok := ParseAnimeName(file.Info.Name)
+		if !ok {
+			continue
+		}
+		if crc, ok := an.CRC32(); ok {
+			files = append(files, embeddedCRCFile{path: file.FullPath, crc: crc})
+			totalSize += file.Info.Size
+		}
+	}
+
+	if len(files) == 0 {
+		s.log.Info().Str("name", rel.Name).Msg("no files with embedded crc")
+		return report, nil
+	}
+
+	useParallelRead, err := s.useParallelRead(rel.Root.FullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	bar := progress.NewProgressBar(showProgress, totalSize, true)
+
+	for _, file := range files {
+		crcBuilder, err := rel.files.crcBuilder(file.path, file.crc)
+		if err != nil {
+			return nil, err
+		}
+
+		crcChecker := crcBuilder.
+			WithParallelRead(useParallelRead).
+			WithProgressBar(bar).
+			WithContext(s.ctx).
+			WithHashThreads(s.hashThreads).Build()
+
+		if err := crcChecker.VerifyCRC32(); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return nil, fmt.Errorf("check embedded crc: %w", err)
+			}
+
+			s.log.Error().Err(err).Msg("verification failed")
+			report.add(CodeCRCMismatch, SeverityError, file.path, err)
+		}
+	}
+
+	s.log.Info().Str("dur", time.Since(startTime).String()).Msg("embedded crc check complete")
+
+	return report, nil
+}
@@ -0,0 +1,150 @@
+package release_test
+
+import (
+	"fmt"
+	"hash/crc32"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnimeName(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantOK      bool
+		group       string
+		title       string
+		episode     string
+		lastEpisode string
+		version     string
+		resolution  string
+		crc         string
+	}{
+		{
+			name:   "[SubsPlease] Sousou no Frieren - 12 (1080p) [ABCD1234].mkv",
+			wantOK: true, group: "SubsPlease", title: "Sousou no Frieren", episode: "12", resolution: "1080p", crc: "ABCD1234",
+		},
+		{
+			name:   "[Group] Title - 1045v2 [1920x1080][0a1b2c3d]",
+			wantOK: true, group: "Group", title: "Title", episode: "1045", version: "2", resolution: "1920x1080", crc: "0a1b2c3d",
+		},
+		{
+			name:   "[Group] Title (01-24) [BD 1080p HEVC]",
+			wantOK: true, group: "Group", title: "Title", episode: "01", lastEpisode: "24", resolution: "1080p",
+		},
+		{
+			name:   "[Group]_Some_Title_-_03_[720p].mkv",
+			wantOK: true, group: "Group", title: "Some Title", episode: "03", resolution: "720p",
+		},
+		{
+			name:   "[Group] Dr. Stone (2019) - 01 [1080p]",
+			wantOK: true, group: "Group", title: "Dr. Stone", episode: "01", resolution: "1080p",
+		},
+		{name: "Show.S01E01.1080p.WEB.h264-GRP"},
+		{name: "[Group] [1080p]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			an, ok := release.ParseAnimeName(tt.name)
+			require.Equal(t, tt.wantOK, ok)
+
+			assert.Equal(t, tt.group, an.Group.Value)
+			assert.Equal(t, tt.title, an.Title.Value)
+			assert.Equal(t, tt.episode, an.Episode.Value)
+			assert.Equal(t, tt.lastEpisode, an.LastEpisode.Value)
+			assert.Equal(t, tt.version, an.Version.Value)
+			assert.Equal(t, tt.resolution, an.Resolution.Value)
+			assert.Equal(t, tt.crc, an.CRC.Value)
+			assert.Equal(t, tt.lastEpisode != "", an.IsBatch())
+		})
+	}
+}
+
+func TestAnimeName_CRC32(t *testing.T) {
+	an, _ := release.ParseAnimeName("[Group] Title - 01 [1080p][ABCD1234].mkv")
+	crc, ok := an.CRC32()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0xABCD1234), crc)
+
+	an, _ = release.ParseAnimeName("[Group] Title - 01 [1080p].mkv")
+	_, ok = an.CRC32()
+	assert.False(t, ok)
+}
+
+func TestParse_Anime(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	content := []byte("episode content")
+	crc := crc32.ChecksumIEEE(content)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		fmt.Sprintf("[Group] Title (01-03) [1080p]/[Group] Title - 01 [1080p][%08X].mkv", crc): content,
+		fmt.Sprintf("[Group] Title (01-03) [1080p]/[Group] Title - 02 [1080p][%08X].mkv", crc): content,
+		"[Group] Title (01-03) [1080p]/[Group] Title - 03 [1080p][FFFFFFFF].mkv":               content,
+		"[Group] Title - 12 [1080p][ABCD1234].mkv":                                             content,
+	})
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+		WithEpisodeCheck(release.EpisodeCheckStrict).Build()
+	require.NoError(t, err)
+
+	t.Run("batch", func(t *testing.T) {
+		rel, err := releaseService.Parse(filepath.Join(tmpDir, "[Group] Title (01-03) [1080p]"))
+		require.NoError(t, err)
+
+		assert.Equal(t, release.Anime, rel.Section)
+		assert.Equal(t, "Group", rel.Group)
+		assert.Equal(t, "Title", rel.ProductTitle)
+		assert.Empty(t, rel.ForbiddenFiles)
+		require.NotNil(t, rel.Anime)
+		assert.True(t, rel.Anime.IsBatch())
+
+		numbers := make([]int, 0, len(rel.Episodes))
+		for _, episode := range rel.Episodes {
+			numbers = append(numbers, episode.AbsoluteNumber)
+		}
+		assert.Equal(t, []int{1, 2, 3}, numbers)
+
+		report, err := releaseService.CheckEmbeddedCRCReport(rel, false)
+		require.NoError(t, err)
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, release.CodeCRCMismatch, report.Findings[0].Code)
+		assert.Contains(t, report.Findings[0].Path, "[FFFFFFFF]")
+
+		assert.ErrorIs(t, releaseService.CheckEmbeddedCRC(rel, false), release.ErrCRCValidationFailed)
+	})
+
+	t.Run("single episode", func(t *testing.T) {
+		rel, err := releaseService.Parse(filepath.Join(tmpDir, "[Group] Title - 12 [1080p][ABCD1234].mkv"))
+		require.NoError(t, err)
+
+		assert.Equal(t, release.Anime, rel.Section)
+		assert.Equal(t, "Group", rel.Group)
+		assert.Equal(t, release.FHD, rel.TagResolution)
+		require.Len(t, rel.Episodes, 1)
+		assert.Equal(t, 12, rel.Episodes[0].AbsoluteNumber)
+
+		assert.ErrorIs(t, releaseService.CheckEmbeddedCRC(rel, false), release.ErrCRCValidationFailed)
+	})
+
+	t.Run("forbidden characters", func(t *testing.T) {
+		dir := t.TempDir()
+		setupTestDir(t, dir, map[string][]byte{
+			"[Group] Title - 01 [1080p]/[Group] Title - 01 [1080p].mkv": content,
+			"[Group] Title - 01 [1080p]/info#1.txt":                     content,
+		})
+
+		rel, err := releaseService.Parse(filepath.Join(dir, "[Group] Title - 01 [1080p]"))
+		require.ErrorIs(t, err, release.ErrForbiddenCharacters)
+		assert.Equal(t, []string{"info#1.txt"}, rel.ForbiddenFiles.Names())
+		assert.Len(t, rel.Report.ByCode(release.CodeForbiddenCharactersAnime), 1)
+		assert.Empty(t, rel.Report.ByCode(release.CodeForbiddenCharacters))
+	})
+}
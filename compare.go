@@ -0,0 +1,210 @@
+package release
+
+import (
+	"maps"
+	"math"
+	"slices"
+)
+
+// ScoreProfile weighs the quality criteria of Compare. Tokens of the release name (sources, codecs and flags)
+// are compared case-insensitive and without separators, e.g. "WEB-DL" matches the key "webdl".
+type ScoreProfile struct {
+	// Name is the name of the profile.
+	Name string `json:"name" yaml:"name"`
+	// Resolutions is the score per resolution, the mediainfo resolution is preferred over the tag.
+	Resolutions map[Resolution]int `json:"resolutions" yaml:"resolutions"`
+	// Sources is the score per source, e.g. "bluray" or "web".
+	Sources map[string]int `json:"sources" yaml:"sources"`
+	// VideoCodecs is the score per video codec, e.g. "x265" or "h264".
+	VideoCodecs map[string]int `json:"video_codecs" yaml:"video_codecs"`
+	// AudioCodecs is the score per audio codec, e.g. "dtshdma" or "ac3".
+	AudioCodecs map[string]int `json:"audio_codecs" yaml:"audio_codecs"`
+	// HDR (HDR10, HDR10+ or HLG) and DolbyVision are added if the format is found in Info.VideoFormat,
+	// which holds the formats of the release name and the mediainfo.
+	HDR         int `json:"hdr" yaml:"hdr"`
+	DolbyVision int `json:"dolby_vision" yaml:"dolby_vision"`
+	// AudioTrack is added for every audio track of the mediainfo.
+	AudioTrack int `json:"audio_track" yaml:"audio_track"`
+	// Languages is the score per language (see Info.HasAnyLanguage), e.g. "german" or "english".
+	Languages map[string]int `json:"languages" yaml:"languages"`
+	// Flags is the score per release flag, e.g. "proper" or "repack".
+	Flags map[string]int `json:"flags" yaml:"flags"`
+	// Nuked is added for nuked releases, usually negative.
+	Nuked int `json:"nuked" yaml:"nuked"`
+	// SizePerGiB is added for every GiB of the release, negative values prefer smaller releases.
+	SizePerGiB float64 `json:"size_per_gib" yaml:"size_per_gib"`
+}
+
+// DefaultScoreProfile returns a language neutral profile that prefers higher resolutions, better sources
+// and codecs, fixed releases and not nuked releases. The size is ignored.
+func DefaultScoreProfile() ScoreProfile {
+	return ScoreProfile{
+		Name:        "default",
+		Resolutions: map[Resolution]int{SD: 0, SD480: 0, SD576: 0, HD: 100, FHD: 200, UHD: 300, UHD8K: 400},
+		Sources: map[string]int{
+			"uhdbluray": 60, "bluray": 50, "webdl": 40, "web": 35, "webrip": 30, "hdtv": 20, "dvdrip": 10,
+		},
+		VideoCodecs: map[string]int{"x265": 10, "h265": 10, "hevc": 10, "x264": 5, "h264": 5},
+		AudioCodecs: map[string]int{
+			"truehd": 15, "dtshdma": 15, "dtsx": 15, "dtshd": 12, "ddp": 10, "eac3": 10, "dts": 8, "ac3": 5, "dd": 5,
+			"aac": 2,
+		},
+		HDR:         20,
+		DolbyVision: 10,
+		AudioTrack:  5,
+		Flags:       map[string]int{"proper": 15, "repack": 15, "rerip": 15, "real": 5},
+		Nuked:       -1000,
+	}
+}
+
+// Comparison is the result of Compare.
+type Comparison struct {
+	// Profile is the name of the profile used.
+	Profile string `json:"profile"`
+	// ScoreA and ScoreB are the total scores of both releases.
+	ScoreA int `json:"score_a"`
+	ScoreB int `json:"score_b"`
+	// Criteria holds all criteria with different scores, they explain the result.
+	Criteria []CriterionScore `json:"criteria"`
+}
+
+// CriterionScore is the score of a single criterion for both releases.
+type CriterionScore struct {
+	// Name is the criterion, e.g. "resolution" or "language german".
+	Name string `json:"name"`
+	// A and B are the scores of both releases.
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+// Result returns -1 if a is better, 1 if b is better and 0 if both are equal.
+func (c Comparison) Result() int {
+	switch {
+	case c.ScoreA > c.ScoreB:
+		return -1
+	case c.ScoreA < c.ScoreB:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Compare compares two releases with the DefaultScoreProfile.
+func Compare(a, b *Info) Comparison {
+	return DefaultScoreProfile().Compare(a, b)
+}
+
+// Compare compares two releases of the same product and explains which one is better.
+func (p ScoreProfile) Compare(a, b *Info) Comparison {
+	comparison := Comparison{Profile: p.Name}
+
+	scoresA, scoresB := p.scores(a), p.scores(b)
+
+	for i, scoreA := range scoresA {
+		scoreB := scoresB[i]
+
+		comparison.ScoreA += scoreA.A
+		comparison.ScoreB += scoreB.A
+
+		if scoreA.A != scoreB.A {
+			comparison.Criteria = append(comparison.Criteria, CriterionScore{Name: scoreA.Name, A: scoreA.A, B: scoreB.A})
+		}
+	}
+
+	return comparison
+}
+
+// Score returns the total score of a single release.
+func (p ScoreProfile) Score(rel *Info) int {
+	total := 0
+	for _, score := range p.scores(rel) {
+		total += score.A
+	}
+	return total
+}
+
+// scores returns the score of every criterion in a fixed order, only A of the criterion scores is set.
+func (p ScoreProfile) scores(rel *Info) []CriterionScore {
+	resolution := rel.TagResolution
+	if rel.MediaInfo != nil {
+		if nearest := rel.MediaInfo.GetNearestResolution(); nearest != "" {
+			resolution = nearest
+		}
+	}
+
+	scores := []CriterionScore{
+		{Name: "resolution", A: p.Resolutions[resolution]},
+		{Name: "source", A: tokenScore(p.Sources, rel.ReleaseName.Source)},
+		{Name: "video codec", A: tokenScore(p.VideoCodecs, rel.ReleaseName.VideoCodec)},
+		{Name: "audio codec", A: tokenScore(p.AudioCodecs, rel.ReleaseName.AudioCodec)},
+		{Name: "hdr", A: boolScore(rel.VideoFormat.HasHDR(HDR10, HDR10Plus, HLG), p.HDR)},
+		{Name: "dolby vision", A: boolScore(rel.VideoFormat.HasHDR(DolbyVision), p.DolbyVision)},
+		{Name: "audio tracks", A: p.AudioTrack * countAudioTracks(rel.MediaInfo)},
+	}
+
+	for _, language := range sortedKeys(p.Languages) {
+		scores = append(scores, CriterionScore{
+			Name: "language " + language,
+			A:    boolScore(rel.HasAnyLanguage(language), p.Languages[language]),
+		})
+	}
+
+	for _, flag := range sortedKeys(p.Flags) {
+		scores = append(scores, CriterionScore{
+			Name: "flag " + flag,
+			A:    boolScore(rel.HasFlag(flag), p.Flags[flag]),
+		})
+	}
+
+	scores = append(scores,
+		CriterionScore{Name: "nuke", A: boolScore(rel.HasNuke(), p.Nuked)},
+		CriterionScore{Name: "size", A: int(math.Round(float64(rel.Size) / (1 << 30) * p.SizePerGiB))},
+	)
+
+	return scores
+}
+
+// tokenScore returns the score of the token, the keys of the scores are normalized like the token.
+func tokenScore(scores map[string]int, token Token) int {
+	if !token.Found() {
+		return 0
+	}
+
+	value := token.normalized()
+	for key, score := range scores {
+		if (Token{Value: key}).normalized() == value {
+			return score
+		}
+	}
+
+	return 0
+}
+
+// boolScore returns the score if ok is true, otherwise 0.
+func boolScore(ok bool, score int) int {
+	if ok {
+		return score
+	}
+	return 0
+}
+
+// countAudioTracks returns the number of audio tracks of the mediainfo.
+func countAudioTracks(mi *MediaInfo) int {
+	if mi == nil {
+		return 0
+	}
+
+	count := 0
+	for _, track := range mi.Media.Tracks {
+		if track.Type == string(Audio) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// sortedKeys returns the sorted keys of the map, so the criteria have a stable order.
+func sortedKeys(m map[string]int) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,147 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	newRelease := func(name string, resolution release.Resolution, language string, size int64) *release.Info {
+		return &release.Info{
+			Name:          name,
+			ReleaseName:   release.ParseName(name),
+			VideoFormat:   release.ParseVideoFormat(name),
+			TagResolution: resolution,
+			Language:      language,
+			Size:          size,
+		}
+	}
+
+	germanDL := newRelease("Movie.2020.German.DL.1080p.WEB.x264-Group", release.FHD, "german", 6<<30)
+	germanDL.MediaInfo = &release.MediaInfo{
+		Media: release.Media{
+			Tracks: []release.MediaInfoTrack{
+				{Type: "Video", Width: "1920", Height: "1080"},
+				{Type: string(release.Audio), Language: "de"},
+				{Type: string(release.Audio), Language: "en"},
+			},
+		},
+	}
+
+	english := newRelease("Movie.2020.2160p.UHD.BluRay.x265.HDR-Group", release.UHD, "", 40<<30)
+
+	proper := newRelease("Movie.2020.PROPER.1080p.BluRay.x264-Group", release.FHD, "", 10<<30)
+	original := newRelease("Movie.2020.1080p.BluRay.x264-Other", release.FHD, "", 10<<30)
+
+	nuked := newRelease("Movie.2020.2160p.UHD.BluRay.x265-Other", release.UHD, "", 40<<30)
+	nuked.PreInfo = &release.Pre{Nuke: "bad.ivtc"}
+
+	germanProfile := release.DefaultScoreProfile()
+	germanProfile.Name = "german-dl"
+	germanProfile.Languages = map[string]int{"german": 1000, "english": 100}
+	germanProfile.SizePerGiB = -1
+
+	englishProfile := release.DefaultScoreProfile()
+	englishProfile.Name = "english"
+	englishProfile.Languages = map[string]int{"german": -50}
+
+	tests := []struct {
+		desc     string
+		profile  release.ScoreProfile
+		a, b     *release.Info
+		expected int
+	}{
+		{
+			desc:     "default prefers resolution and hdr",
+			profile:  release.DefaultScoreProfile(),
+			a:        germanDL,
+			b:        english,
+			expected: 1,
+		},
+		{
+			desc:     "german profile prefers the german dual language release",
+			profile:  germanProfile,
+			a:        germanDL,
+			b:        english,
+			expected: -1,
+		},
+		{
+			desc:     "english profile prefers the english release",
+			profile:  englishProfile,
+			a:        germanDL,
+			b:        english,
+			expected: 1,
+		},
+		{
+			desc:     "proper wins",
+			profile:  release.DefaultScoreProfile(),
+			a:        original,
+			b:        proper,
+			expected: 1,
+		},
+		{
+			desc:     "nuked loses",
+			profile:  release.DefaultScoreProfile(),
+			a:        nuked,
+			b:        original,
+			expected: 1,
+		},
+		{
+			desc:     "equal releases",
+			profile:  release.DefaultScoreProfile(),
+			a:        original,
+			b:        original,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := tt.profile.Compare(tt.a, tt.b)
+			assert.Equal(t, tt.expected, got.Result())
+			assert.Equal(t, tt.profile.Name, got.Profile)
+
+			// every criterion in the result has to explain a difference
+			for _, criterion := range got.Criteria {
+				assert.NotEqual(t, criterion.A, criterion.B, criterion.Name)
+			}
+		})
+	}
+
+	t.Run("criteria explain the result", func(t *testing.T) {
+		got := germanProfile.Compare(germanDL, english)
+
+		names := make([]string, 0, len(got.Criteria))
+		for _, criterion := range got.Criteria {
+			names = append(names, criterion.Name)
+		}
+
+		assert.Contains(t, names, "resolution")
+		assert.Contains(t, names, "hdr")
+		assert.Contains(t, names, "language german")
+		assert.Contains(t, names, "audio tracks")
+		assert.Contains(t, names, "size")
+		assert.NotContains(t, names, "nuke")
+	})
+
+	t.Run("hdr of the mediainfo", func(t *testing.T) {
+		sdr := newRelease("Movie.2020.2160p.UHD.BluRay.x265-Group", release.UHD, "", 40<<30)
+		dv := newRelease("Movie.2020.2160p.UHD.BluRay.x265-Other", release.UHD, "", 40<<30)
+		dv.VideoFormat.HDR = []release.HDRFormat{release.DolbyVision, release.HDR10}
+
+		got := release.Compare(dv, sdr)
+		assert.Equal(t, -1, got.Result())
+
+		names := make([]string, 0, len(got.Criteria))
+		for _, criterion := range got.Criteria {
+			names = append(names, criterion.Name)
+		}
+		assert.ElementsMatch(t, []string{"hdr", "dolby vision"}, names)
+	})
+
+	t.Run("package compare uses the default profile", func(t *testing.T) {
+		assert.Equal(t, release.DefaultScoreProfile().Compare(germanDL, english), release.Compare(germanDL, english))
+	})
+}
@@ -0,0 +1,229 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/f4n4t/go-dtree"
+)
+
+var (
+	// discFolderPattern matches the subfolders of multi-disc releases like CD1, Disc2, Disk_1 or DVD1.
+	discFolderPattern = regexp.MustCompile(`(?i)^(?:cd|dis[ck]|dvd)[._ -]?(\d{1,2})$`)
+)
+
+var (
+	// ErrDiscValidationFailed indicates that the check of the discs has failed.
+	ErrDiscValidationFailed = errors.New("disc check failed")
+
+	// ErrMissingDisc is the error of a disc number that is missing between the discs of a release.
+	ErrMissingDisc = errors.New("missing disc")
+
+	// ErrEmptyDisc is the error of a disc without archives and media files.
+	ErrEmptyDisc = errors.New("empty disc")
+
+	// ErrMissingSfv is the error of a disc with archives but without a sfv file.
+	ErrMissingSfv = errors.New("missing sfv file")
+
+	// ErrUnlistedFile is the error of an archive that is not listed in the sfv file of its disc.
+	ErrUnlistedFile = errors.New("file not listed in sfv")
+
+	// ErrDiscMismatch is the error of a disc whose content differs from the first disc.
+	ErrDiscMismatch = errors.New("disc mismatch")
+)
+
+// Disc is a single disc of a multi-disc release, e.g. the CD1 folder with its own archives and sfv.
+type Disc struct {
+	// Number is the number of the disc parsed from the folder name.
+	Number int `json:"number"`
+	// Path is the FullPath of the disc folder.
+	Path string `json:"path"`
+	// Root is the node of the disc folder.
+	Root *dtree.Node `json:"-"`
+	// Sfv is the first sfv file of the disc, nil if it has none.
+	Sfv *dtree.Node `json:"-"`
+	// Archives holds all archive files of the disc.
+	Archives []*dtree.Node `json:"-"`
+	// Media holds all media files of the disc.
+	Media []*dtree.Node `json:"-"`
+}
+
+// findDiscs returns the disc folders directly inside the root, sorted by their number.
+// Meta folders (e.g. Sample or Subs) inside a disc are not part of the disc content.
+func findDiscs(root *dtree.Node) []Disc {
+	var discs []Disc
+
+	for _, child := range root.Children {
+		if !child.Info.IsDir {
+			continue
+		}
+
+		m := discFolderPattern.FindStringSubmatch(child.Info.Name)
+		if m == nil {
+			continue
+		}
+
+		number, _ := strconv.Atoi(m[1])
+		discs = append(discs, newDisc(number, child))
+	}
+
+	slices.SortStableFunc(discs, func(a, b Disc) int {
+		return a.Number - b.Number
+	})
+
+	return discs
+}
+
+// newDisc collects the sfv, archive and media files of the disc folder.
+func newDisc(number int, node *dtree.Node) Disc {
+	disc := Disc{Number: number, Path: node.FullPath, Root: node}
+
+	var collect func(node *dtree.Node)
+
+	collect = func(node *dtree.Node) {
+		for _, child := range node.Children {
+			switch {
+			case child.Info.IsDir:
+				if !Regexes.MetaFolders.MatchString(child.Info.Name) {
+					collect(child)
+				}
+
+			case child.Info.Extension == ".sfv":
+				if disc.Sfv == nil {
+					disc.Sfv = child
+				}
+
+			case Regexes.Archive.MatchString(child.Info.Extension):
+				disc.Archives = append(disc.Archives, child)
+
+			case Regexes.Media.MatchString(child.Info.Extension):
+				disc.Media = append(disc.Media, child)
+			}
+		}
+	}
+
+	collect(node)
+
+	return disc
+}
+
+// mediaInfoFile returns the file of the disc used for the mediainfo generation, the first rar volume or the
+// biggest media file.
+func (d Disc) mediaInfoFile() *dtree.Node {
+	if node, err := getRarForMediaInfo(d.Root); err == nil {
+		return node
+	}
+
+	var biggest *dtree.Node
+	for _, node := range d.Media {
+		if biggest == nil || node.Info.Size > biggest.Info.Size {
+			biggest = node
+		}
+	}
+
+	return biggest
+}
+
+// volumeSize returns the size of the biggest archive volume of the disc.
+func (d Disc) volumeSize() int64 {
+	var size int64
+	for _, node := range d.Archives {
+		size = max(size, node.Info.Size)
+	}
+	return size
+}
+
+// CheckDiscs verifies that every disc of a multi-disc release is complete and consistent with the other discs:
+// no disc number is missing, every disc has content, archives are listed completely in the sfv of their disc and
+// all discs have the same kind of content and volume size. The CRCs are checked by CheckSFV. On failure a
+// *ReportError is returned that matches ErrDiscValidationFailed and the errors of all findings.
+func (s *Service) CheckDiscs(rel *Info) error {
+	report, err := s.CheckDiscsReport(rel)
+	if err != nil {
+		return err
+	}
+
+	return report.err(ErrDiscValidationFailed)
+}
+
+// CheckDiscsReport works like CheckDiscs, but returns the problems of the discs as report.
+// An error is only returned if the sfv files can't be read at all, e.g. for a release parsed by ParseListing.
+func (s *Service) CheckDiscsReport(rel *Info) (*Report, error) {
+	report := &Report{}
+
+	if len(rel.Discs) == 0 {
+		return report, nil
+	}
+
+	if !rel.files.hasContent() && slices.ContainsFunc(rel.Discs, func(d Disc) bool { return d.Sfv != nil }) {
+		return nil, ErrNoContent
+	}
+
+	// every disc between 1 and the highest disc is expected
+	for number := 1; number < rel.Discs[len(rel.Discs)-1].Number; number++ {
+		if !slices.ContainsFunc(rel.Discs, func(d Disc) bool { return d.Number == number }) {
+			discErr := fmt.Errorf("%w: %d", ErrMissingDisc, number)
+			s.log.Error().Str("name", rel.Name).Err(discErr).Msg("")
+			report.add(CodeMissingDisc, SeverityError, "", discErr)
+		}
+	}
+
+	first := rel.Discs[0]
+
+	for _, disc := range rel.Discs {
+		if len(disc.Archives) == 0 && len(disc.Media) == 0 {
+			s.log.Error().Str("disc", disc.Root.Info.Name).Err(ErrEmptyDisc).Msg("")
+			report.add(CodeEmptyDisc, SeverityError, disc.Path, ErrEmptyDisc)
+			continue
+		}
+
+		if (len(disc.Archives) > 0) != (len(first.Archives) > 0) {
+			discErr := fmt.Errorf("%w: archives don't match %s", ErrDiscMismatch, first.Root.Info.Name)
+			s.log.Error().Str("disc", disc.Root.Info.Name).Err(discErr).Msg("")
+			report.add(CodeDiscMismatch, SeverityError, disc.Path, discErr)
+		} else if disc.volumeSize() > 0 && disc.volumeSize() != first.volumeSize() {
+			discErr := fmt.Errorf("%w: volume size %d instead of %d", ErrDiscMismatch, disc.volumeSize(),
+				first.volumeSize())
+			s.log.Warn().Str("disc", disc.Root.Info.Name).Err(discErr).Msg("")
+			report.add(CodeDiscMismatch, SeverityWarning, disc.Path, discErr)
+		}
+
+		if err := s.checkDiscSfv(rel, disc, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// checkDiscSfv checks that the sfv of the disc exists and lists every archive of the disc.
+// Files listed in the sfv that don't exist are added to the report by readSFVFiles.
+func (s *Service) checkDiscSfv(rel *Info, disc Disc, report *Report) error {
+	if disc.Sfv == nil {
+		if len(disc.Archives) > 0 {
+			s.log.Error().Str("disc", disc.Root.Info.Name).Err(ErrMissingSfv).Msg("")
+			report.add(CodeMissingSfv, SeverityError, disc.Path, ErrMissingSfv)
+		}
+		return nil
+	}
+
+	entries, err := readSFVFiles(rel.files, disc.Sfv.FullPath, report)
+	if errors.Is(err, ErrInvalidSfv) {
+		report.add(CodeInvalidSfv, SeverityError, disc.Sfv.FullPath, err)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get files from sfv: %w", err)
+	}
+
+	for _, archive := range disc.Archives {
+		if !slices.ContainsFunc(entries, func(entry sfvFile) bool { return entry.path == archive.FullPath }) {
+			s.log.Error().Str("name", archive.Info.Name).Err(ErrUnlistedFile).Msg("")
+			report.add(CodeUnlistedFile, SeverityError, archive.FullPath, ErrUnlistedFile)
+		}
+	}
+
+	return nil
+}
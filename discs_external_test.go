@@ -0,0 +1,142 @@
+package release_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Discs(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Movie.1999.German.DVDRip.XviD-Group/CD2/movie-cd2.rar":         []byte("abcd"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD2/movie-cd2.r00":         []byte("abcde"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD2/movie-cd2.sfv":         []byte("movie-cd2.rar 00000000\n"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD1/movie-cd1.rar":         []byte("abcd"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD1/movie-cd1.r00":         []byte("abcde"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD1/movie-cd1.sfv":         []byte("movie-cd1.rar 00000000\n"),
+		"Movie.1999.German.DVDRip.XviD-Group/CD1/Sample/sample.avi":     []byte("sample"),
+		"Movie.1999.German.DVDRip.XviD-Group/Subs/movie-subs.rar":       []byte("subs"),
+		"Movie.1999.German.DVDRip.XviD-Group/movie.1999.german-grp.nfo": []byte("nfo\n"),
+	})
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse(filepath.Join(tmpDir, "Movie.1999.German.DVDRip.XviD-Group"))
+	require.NoError(t, err)
+
+	require.Len(t, rel.Discs, 2)
+	for i, disc := range rel.Discs {
+		assert.Equal(t, i+1, disc.Number)
+		require.NotNil(t, disc.Sfv)
+		assert.Len(t, disc.Archives, 2)
+		assert.Empty(t, disc.Media, "sample is not part of the disc")
+	}
+	assert.Equal(t, "CD1", rel.Discs[0].Root.Info.Name)
+	assert.Equal(t, "movie-cd2.sfv", rel.Discs[1].Sfv.Info.Name)
+
+	t.Run("load info", func(t *testing.T) {
+		data, err := rel.MarshalFull()
+		require.NoError(t, err)
+
+		loaded, err := release.LoadInfo(data)
+		require.NoError(t, err)
+
+		require.Len(t, loaded.Discs, 2)
+		node, err := loaded.Root.GetFile("movie-cd2.sfv")
+		require.NoError(t, err)
+		assert.Same(t, node, loaded.Discs[1].Sfv)
+	})
+}
+
+func TestRelease_CheckDiscs(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tests := []struct {
+		desc      string
+		testFiles map[string][]byte
+		expected  []release.FindingCode
+	}{
+		{
+			desc: "complete discs",
+			testFiles: map[string][]byte{
+				"CD1/movie-cd1.rar": []byte("abcd"),
+				"CD1/movie-cd1.r00": []byte("abcd"),
+				"CD1/movie-cd1.sfv": []byte("movie-cd1.rar 00000000\nmovie-cd1.r00 00000000\n"),
+				"CD2/movie-cd2.rar": []byte("ab"),
+				"CD2/movie-cd2.r00": []byte("abcd"),
+				"CD2/movie-cd2.sfv": []byte("movie-cd2.rar 00000000\nmovie-cd2.r00 00000000\n"),
+			},
+		},
+		{
+			desc: "unpacked discs without sfv",
+			testFiles: map[string][]byte{
+				"Disc1/movie-cd1.avi": []byte("abcd"),
+				"Disc2/movie-cd2.avi": []byte("abc"),
+			},
+		},
+		{
+			desc: "missing disc",
+			testFiles: map[string][]byte{
+				"CD1/movie-cd1.avi": []byte("abcd"),
+				"CD3/movie-cd3.avi": []byte("abc"),
+			},
+			expected: []release.FindingCode{release.CodeMissingDisc},
+		},
+		{
+			desc: "incomplete sfv",
+			testFiles: map[string][]byte{
+				"CD1/movie-cd1.rar": []byte("abcd"),
+				"CD1/movie-cd1.r00": []byte("abcd"),
+				"CD1/movie-cd1.sfv": []byte("movie-cd1.rar 00000000\nmovie-cd1.r01 00000000\n"),
+			},
+			expected: []release.FindingCode{release.CodeMissingFile, release.CodeUnlistedFile},
+		},
+		{
+			desc: "missing sfv and mismatching discs",
+			testFiles: map[string][]byte{
+				"CD1/movie-cd1.rar": []byte("abcd"),
+				"CD1/movie-cd1.sfv": []byte("movie-cd1.rar 00000000\n"),
+				"CD2/movie-cd2.rar": []byte("abcdef"),
+				"CD3/movie-cd3.avi": []byte("abcd"),
+				"CD4/movie-cd4.nfo": []byte("nfo"),
+			},
+			expected: []release.FindingCode{
+				release.CodeDiscMismatch, release.CodeMissingSfv, release.CodeDiscMismatch, release.CodeEmptyDisc,
+			},
+		},
+	}
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestDir(t, tmpDir, tt.testFiles)
+
+			rel, err := releaseService.Parse(tmpDir)
+			require.NoError(t, err)
+
+			report, err := releaseService.CheckDiscsReport(rel)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, findingCodes(report.Findings))
+
+			err = releaseService.CheckDiscs(rel)
+			if len(report.Errors()) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, release.ErrDiscValidationFailed)
+		})
+	}
+}
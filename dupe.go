@@ -0,0 +1,255 @@
+package release
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DupeMode selects how DupeIndex.Lookup matches releases.
+type DupeMode int
+
+const (
+	// DupeExact matches releases with the same normalized title, year, season and episode.
+	DupeExact DupeMode = iota
+	// DupeFuzzy matches releases with the same season and episode whose normalized titles are within the
+	// edit distance of the index. A missing year matches every year.
+	DupeFuzzy
+	// DupeSameEpisode matches releases of DupeExact with a different quality (resolution, source or codec).
+	DupeSameEpisode
+)
+
+// DupeKey identifies a product (and the episode of a series) independent of the release.
+type DupeKey struct {
+	// Title is the normalized product title, see NormalizeTitle.
+	Title string `json:"title"`
+	// Year is the product year, 0 if the release name has none.
+	Year int `json:"year"`
+	// Season is the season number, 0 if the release is not part of a series.
+	Season int `json:"season"`
+	// Episode is the episode number, 0 for season packs.
+	Episode int `json:"episode"`
+}
+
+// NewDupeKey creates the key of the release.
+func NewDupeKey(rel *Info) DupeKey {
+	title := rel.ProductTitle
+	if title == "" {
+		title = rel.ReleaseName.Title.Value
+	}
+
+	return DupeKey{
+		Title:   NormalizeTitle(title),
+		Year:    rel.ProductYear,
+		Season:  rel.ReleaseName.Season.Int(),
+		Episode: rel.ReleaseName.Episode.Int(),
+	}
+}
+
+// DupeIndex groups releases by their DupeKey and finds the existing releases matching a new one.
+// It is safe for concurrent use.
+type DupeIndex struct {
+	mu          sync.RWMutex
+	maxDistance int
+	releases    map[DupeKey][]*Info
+}
+
+// NewDupeIndex creates an empty index, maxDistance is the maximum edit distance of the titles in DupeFuzzy.
+func NewDupeIndex(maxDistance int) *DupeIndex {
+	return &DupeIndex{
+		maxDistance: maxDistance,
+		releases:    make(map[DupeKey][]*Info),
+	}
+}
+
+// Add adds the releases to the index, releases with a name that is already indexed are ignored.
+func (d *DupeIndex) Add(releases ...*Info) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, rel := range releases {
+		key := NewDupeKey(rel)
+
+		if !containsRelease(d.releases[key], rel.Name) {
+			d.releases[key] = append(d.releases[key], rel)
+		}
+	}
+}
+
+// Remove removes the release with the same name from the index.
+func (d *DupeIndex) Remove(rel *Info) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := NewDupeKey(rel)
+
+	for i, indexed := range d.releases[key] {
+		if indexed.Name == rel.Name {
+			d.releases[key] = append(d.releases[key][:i], d.releases[key][i+1:]...)
+			break
+		}
+	}
+
+	if len(d.releases[key]) == 0 {
+		delete(d.releases, key)
+	}
+}
+
+// Len returns the count of all indexed releases.
+func (d *DupeIndex) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	count := 0
+	for _, releases := range d.releases {
+		count += len(releases)
+	}
+
+	return count
+}
+
+// Lookup returns all indexed releases matching the given release with the mode, the release itself
+// (same name) is never returned.
+func (d *DupeIndex) Lookup(rel *Info, mode DupeMode) []*Info {
+	return d.lookup(rel, mode, true)
+}
+
+// lookup works like Lookup, an indexed release with the same name is only skipped with excludeSelf.
+func (d *DupeIndex) lookup(rel *Info, mode DupeMode, excludeSelf bool) []*Info {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	key := NewDupeKey(rel)
+
+	var matches []*Info
+
+	switch mode {
+	case DupeExact:
+		matches = append(matches, d.releases[key]...)
+
+	case DupeSameEpisode:
+		for _, indexed := range d.releases[key] {
+			if !sameQuality(rel, indexed) {
+				matches = append(matches, indexed)
+			}
+		}
+
+	case DupeFuzzy:
+		for indexedKey, releases := range d.releases {
+			if indexedKey.Season != key.Season || indexedKey.Episode != key.Episode {
+				continue
+			}
+
+			if indexedKey.Year != key.Year && indexedKey.Year != 0 && key.Year != 0 {
+				continue
+			}
+
+			if editDistance(indexedKey.Title, key.Title) <= d.maxDistance {
+				matches = append(matches, releases...)
+			}
+		}
+	}
+
+	if !excludeSelf {
+		return matches
+	}
+
+	// the release could already be indexed
+	for i, match := range matches {
+		if match.Name == rel.Name {
+			return append(matches[:i], matches[i+1:]...)
+		}
+	}
+
+	return matches
+}
+
+// containsRelease checks if a release with the name exists.
+func containsRelease(releases []*Info, name string) bool {
+	for _, rel := range releases {
+		if rel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sameQuality checks if both releases have the same resolution, source and video codec.
+func sameQuality(a, b *Info) bool {
+	return a.TagResolution == b.TagResolution &&
+		a.ReleaseName.Source.normalized() == b.ReleaseName.Source.normalized() &&
+		a.ReleaseName.VideoCodec.normalized() == b.ReleaseName.VideoCodec.normalized()
+}
+
+// titleArticles are removed from the titles, as they are often dropped or translated in release names.
+var titleArticles = map[string]struct{}{
+	"a": {}, "an": {}, "the": {}, "der": {}, "die": {}, "das": {},
+}
+
+// romanNumerals maps the roman numerals of sequels to their value. The single letters "v" and "x" are left out,
+// as they are mostly words or names, e.g. "V for Vendetta" or "Malcolm X".
+var romanNumerals = map[string]int{
+	"ii": 2, "iii": 3, "iv": 4, "vi": 6, "vii": 7, "viii": 8, "ix": 9,
+	"xi": 11, "xii": 12, "xiii": 13, "xiv": 14, "xv": 15, "xvi": 16, "xvii": 17, "xviii": 18, "xix": 19, "xx": 20,
+}
+
+// NormalizeTitle normalizes a product title for comparison: it is lowercased, "&" becomes "and",
+// punctuation is removed, articles are dropped and a roman numeral at the end of the title (a sequel number)
+// is converted to a number, e.g. "The Fast & the Furious II" becomes "fast and furious 2".
+func NormalizeTitle(title string) string {
+	title = strings.ToLower(strings.ReplaceAll(title, "&", " and "))
+
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	normalized := make([]string, 0, len(words))
+
+	for _, word := range words {
+		word = strings.ReplaceAll(word, "'", "")
+
+		if _, ok := titleArticles[word]; ok || word == "" {
+			continue
+		}
+
+		normalized = append(normalized, word)
+	}
+
+	// a numeral without a preceding title word is the title itself, e.g. "III"
+	if last := len(normalized) - 1; last > 0 {
+		if number, ok := romanNumerals[normalized[last]]; ok {
+			normalized[last] = strconv.Itoa(number)
+		}
+	}
+
+	return strings.Join(normalized, " ")
+}
+
+// editDistance returns the levenshtein distance of both strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,122 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "The Fast & the Furious", expected: "fast and furious"},
+		{input: "Fast and Furious", expected: "fast and furious"},
+		{input: "Rocky II", expected: "rocky 2"},
+		{input: "Star Wars: Episode IV", expected: "star wars episode 4"},
+		{input: "Rocky II: The Return", expected: "rocky ii return"},
+		{input: "Malcolm X", expected: "malcolm x"},
+		{input: "V for Vendetta", expected: "v for vendetta"},
+		{input: "Grey's Anatomy", expected: "greys anatomy"},
+		{input: "Die Hard", expected: "hard"},
+		{input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, release.NormalizeTitle(tt.input))
+		})
+	}
+}
+
+func TestDupeIndex_Lookup(t *testing.T) {
+	newRelease := func(name string, year int, resolution release.Resolution) *release.Info {
+		rn := release.ParseName(name)
+		return &release.Info{
+			Name:          name,
+			ReleaseName:   rn,
+			ProductTitle:  rn.Title.Value,
+			ProductYear:   year,
+			TagResolution: resolution,
+		}
+	}
+
+	episode720 := newRelease("The.Show.S01E02.720p.HDTV.x264-Group", 0, release.HD)
+	episode1080 := newRelease("Show.S01E02.1080p.WEB.h264-Other", 0, release.FHD)
+	otherEpisode := newRelease("The.Show.S01E03.720p.HDTV.x264-Group", 0, release.HD)
+	movie := newRelease("Rocky.II.1979.1080p.BluRay.x264-Group", 1979, release.FHD)
+	remake := newRelease("Rocky.II.2030.1080p.BluRay.x264-Group", 2030, release.FHD)
+	typo := newRelease("Rocky.2.1979.1080p.WEB.x264-Typo", 1979, release.FHD)
+	typoTitle := newRelease("Rocki.II.1979.720p.BluRay.x264-Typo", 1979, release.HD)
+
+	index := release.NewDupeIndex(1)
+	index.Add(episode720, episode1080, otherEpisode, movie, remake, movie)
+
+	assert.Equal(t, 5, index.Len())
+
+	names := func(releases []*release.Info) []string {
+		var n []string
+		for _, rel := range releases {
+			n = append(n, rel.Name)
+		}
+		return n
+	}
+
+	tests := []struct {
+		desc     string
+		input    *release.Info
+		mode     release.DupeMode
+		expected []string
+	}{
+		{
+			desc:     "exact episode",
+			input:    newRelease("THE.SHOW.S01E02.German.720p.WEB.x264-New", 0, release.HD),
+			mode:     release.DupeExact,
+			expected: []string{episode720.Name, episode1080.Name},
+		},
+		{
+			desc:     "exact ignores the release itself",
+			input:    episode720,
+			mode:     release.DupeExact,
+			expected: []string{episode1080.Name},
+		},
+		{
+			desc:     "exact roman numerals and year",
+			input:    typo,
+			mode:     release.DupeExact,
+			expected: []string{movie.Name},
+		},
+		{
+			desc:     "same episode different quality",
+			input:    newRelease("Show.S01E02.720p.HDTV.x264-New", 0, release.HD),
+			mode:     release.DupeSameEpisode,
+			expected: []string{episode1080.Name},
+		},
+		{
+			desc:     "fuzzy title",
+			input:    typoTitle,
+			mode:     release.DupeFuzzy,
+			expected: []string{movie.Name},
+		},
+		{
+			desc:     "no match",
+			input:    newRelease("Another.Show.S01E02.720p.HDTV.x264-New", 0, release.HD),
+			mode:     release.DupeFuzzy,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.expected, names(index.Lookup(tt.input, tt.mode)))
+		})
+	}
+
+	t.Run("remove", func(t *testing.T) {
+		index.Remove(episode1080)
+		assert.Equal(t, 4, index.Len())
+		assert.Empty(t, index.Lookup(episode720, release.DupeExact))
+	})
+}
@@ -0,0 +1,27 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "", expected: 0},
+		{a: "rocky", b: "", expected: 5},
+		{a: "rocky", b: "rocki", expected: 1},
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "größe", b: "grösse", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, editDistance(tt.a, tt.b))
+			assert.Equal(t, tt.expected, editDistance(tt.b, tt.a))
+		})
+	}
+}
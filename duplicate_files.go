@@ -0,0 +1,249 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/f4n4t/go-dtree"
+	"github.com/f4n4t/go-release/pkg/utils"
+)
+
+// DuplicateCheck defines if Parse searches for files with the same content inside the release.
+type DuplicateCheck int
+
+const (
+	// DuplicateCheckDisabled disables the search for duplicate files.
+	DuplicateCheckDisabled DuplicateCheck = iota
+	// DuplicateCheckEnabled adds hardlinks and files with identical content to Info.DuplicateFiles.
+	DuplicateCheckEnabled
+	// DuplicateCheckForbidden works like DuplicateCheckEnabled and adds every file except the first of a group
+	// to the forbidden files.
+	DuplicateCheckForbidden
+)
+
+// DuplicateKind is the kind of duplicate group.
+type DuplicateKind string
+
+const (
+	// DuplicateHardlink is a group of hardlinks to the same file (same device and inode).
+	DuplicateHardlink DuplicateKind = "hardlink"
+	// DuplicateContent is a group of different files with identical content.
+	DuplicateContent DuplicateKind = "content"
+)
+
+// sampleSize is the size of the samples read from the beginning, the middle and the end of a file before
+// the full CRC is calculated.
+const sampleSize = 64 * 1024
+
+// DuplicateGroup is a group of files with the same content, sorted by their FullPath.
+type DuplicateGroup struct {
+	Kind DuplicateKind `json:"kind"`
+	// Size is the size of every file in the group.
+	Size int64 `json:"size"`
+	// CRC is the CRC32 of the content, it is only calculated for DuplicateContent.
+	CRC uint32 `json:"crc,omitempty"`
+	// Paths holds the FullPath of all files.
+	Paths []string `json:"paths"`
+	// Files holds the nodes of all files.
+	Files []*dtree.Node `json:"-"`
+}
+
+// fileKey identifies a file on the disk.
+type fileKey struct {
+	dev, ino uint64
+}
+
+// findDuplicateFiles searches for hardlinks and files with identical content. Files are first grouped by their
+// size, then by a hash of samples of the content and only the remaining candidates are hashed completely.
+// Symlinks and the files in followed symlink folders are skipped, they share the file key and the content
+// of their target and are already recorded in Info.Symlinks.
+func (s *Service) findDuplicateFiles(info *Info) error {
+	var files []*dtree.Node
+
+	walkNodes(info.Root, func(node *dtree.Node) {
+		if !node.Info.IsDir && node.Info.Size > 0 && !info.viaSymlink(node.FullPath) {
+			files = append(files, node)
+		}
+	})
+
+	files = s.findHardlinks(info, files)
+
+	bySize := make(map[int64][]*dtree.Node)
+	for _, file := range files {
+		bySize[file.Info.Size] = append(bySize[file.Info.Size], file)
+	}
+
+	for _, size := range slices.Sorted(maps.Keys(bySize)) {
+		candidates := bySize[size]
+		if len(candidates) < 2 {
+			continue
+		}
+
+		bySample, err := groupFiles(candidates, func(file *dtree.Node) (uint32, error) {
+			return info.files.sampleCRC(file.FullPath, size)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, sample := range slices.Sorted(maps.Keys(bySample)) {
+			byCRC, err := groupFiles(bySample[sample], func(file *dtree.Node) (uint32, error) {
+				return info.files.fileCRC(s.ctx, file.FullPath, s.hashThreads)
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, crc := range slices.Sorted(maps.Keys(byCRC)) {
+				s.addDuplicateGroup(info, DuplicateGroup{Kind: DuplicateContent, Size: size, CRC: crc}, byCRC[crc])
+			}
+		}
+	}
+
+	slices.SortFunc(info.DuplicateFiles, func(a, b DuplicateGroup) int {
+		return strings.Compare(a.Paths[0], b.Paths[0])
+	})
+
+	return nil
+}
+
+// findHardlinks adds the hardlink groups of the files and returns the files with only the first of every group.
+func (s *Service) findHardlinks(info *Info, files []*dtree.Node) []*dtree.Node {
+	var (
+		keys    []fileKey
+		byKey   = make(map[fileKey][]*dtree.Node)
+		results = make([]*dtree.Node, 0, len(files))
+	)
+
+	for _, file := range files {
+		fileInfo, err := info.files.stat(file.FullPath)
+		if err != nil {
+			results = append(results, file)
+			continue
+		}
+
+		key, ok := fileKeyOf(fileInfo)
+		if !ok {
+			results = append(results, file)
+			continue
+		}
+
+		if _, exists := byKey[key]; !exists {
+			keys = append(keys, key)
+			results = append(results, file)
+		}
+		byKey[key] = append(byKey[key], file)
+	}
+
+	for _, key := range keys {
+		if group := byKey[key]; len(group) > 1 {
+			s.addDuplicateGroup(info, DuplicateGroup{Kind: DuplicateHardlink, Size: group[0].Info.Size}, group)
+		}
+	}
+
+	return results
+}
+
+// addDuplicateGroup adds a group of at least two files to the release and to the forbidden files if enabled.
+func (s *Service) addDuplicateGroup(info *Info, group DuplicateGroup, files []*dtree.Node) {
+	if len(files) < 2 {
+		return
+	}
+
+	slices.SortFunc(files, func(a, b *dtree.Node) int {
+		return strings.Compare(a.FullPath, b.FullPath)
+	})
+
+	group.Files = files
+	for _, file := range files {
+		group.Paths = append(group.Paths, file.FullPath)
+	}
+
+	info.DuplicateFiles = append(info.DuplicateFiles, group)
+
+	code, dupErr := CodeDuplicateContent, ErrDuplicateContent
+	if group.Kind == DuplicateHardlink {
+		code, dupErr = CodeHardlink, ErrHardlink
+	}
+
+	for _, file := range files[1:] {
+		fileErr := fmt.Errorf("%w: %s", dupErr, files[0].Info.Name)
+
+		if s.duplicateCheck != DuplicateCheckForbidden {
+			s.log.Warn().Str("name", file.Info.Name).Err(fileErr).Msg("")
+			info.addFinding(code, SeverityWarning, file.FullPath, fileErr)
+			continue
+		}
+
+		s.log.Error().Str("name", file.Info.Name).Err(fileErr).Msg("")
+		info.addForbiddenFile(code, file.FullPath, file.Info, fileErr)
+	}
+}
+
+// groupFiles groups the files by the hash returned by fn, groups with only one file are dropped.
+func groupFiles(files []*dtree.Node, fn func(file *dtree.Node) (uint32, error)) (map[uint32][]*dtree.Node, error) {
+	groups := make(map[uint32][]*dtree.Node)
+
+	for _, file := range files {
+		hash, err := fn(file)
+		if err != nil {
+			return nil, err
+		}
+		groups[hash] = append(groups[hash], file)
+	}
+
+	for hash, group := range groups {
+		if len(group) < 2 {
+			delete(groups, hash)
+		}
+	}
+
+	return groups, nil
+}
+
+// sampleCRC returns the CRC32 of samples from the beginning, the middle and the end of the file.
+// Files without random access are only sampled at the beginning.
+func (r releaseFS) sampleCRC(fullPath string, size int64) (uint32, error) {
+	f, err := r.open(fullPath)
+	if err != nil {
+		return 0, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	hasher := crc32.NewIEEE()
+
+	readerAt, ok := f.(io.ReaderAt)
+	if !ok || size <= 3*sampleSize {
+		if _, err := io.CopyN(hasher, f, min(size, 3*sampleSize)); err != nil {
+			return 0, fmt.Errorf("read sample: %w", err)
+		}
+		return hasher.Sum32(), nil
+	}
+
+	for _, offset := range []int64{0, size/2 - sampleSize/2, size - sampleSize} {
+		if _, err := io.Copy(hasher, io.NewSectionReader(readerAt, offset, sampleSize)); err != nil {
+			return 0, fmt.Errorf("read sample: %w", err)
+		}
+	}
+
+	return hasher.Sum32(), nil
+}
+
+// fileCRC calculates the CRC32 of the whole file with utils.GetCRC32ParallelFS.
+func (r releaseFS) fileCRC(ctx context.Context, fullPath string, hashThreads int) (uint32, error) {
+	if r.fsys == nil {
+		return utils.GetCRC32Parallel(ctx, fullPath, hashThreads)
+	}
+
+	name, err := r.fsPath(fullPath)
+	if err != nil {
+		return 0, err
+	}
+
+	return utils.GetCRC32ParallelFS(ctx, r.fsys, name, hashThreads)
+}
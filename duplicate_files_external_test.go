@@ -0,0 +1,117 @@
+package release_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_DuplicateFiles(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	big := bytes.Repeat([]byte("0123456789"), 20*1024)
+	// only differs outside the sampled parts
+	bigChanged := bytes.Clone(big)
+	bigChanged[66000] = 'x'
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Test.Release-Group/test.mkv":      []byte("main-content\n"),
+		"Test.Release-Group/test.r00":      big,
+		"Test.Release-Group/test.r01":      big,
+		"Test.Release-Group/test.r02":      bigChanged,
+		"Test.Release-Group/other.nfo":     []byte("nfo-content\n"),
+		"Test.Release-Group/Subs/test.srt": []byte("same-size...\n"),
+	})
+
+	releaseDir := filepath.Join(tmpDir, "Test.Release-Group")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(releaseDir, "Sample"), 0755))
+	if err := os.Link(filepath.Join(releaseDir, "test.mkv"), filepath.Join(releaseDir, "Sample", "sample.mkv")); err != nil {
+		t.Skipf("hardlinks not supported: %v", err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+		require.NoError(t, err)
+
+		rel, err := releaseService.Parse(releaseDir)
+		require.NoError(t, err)
+		assert.Empty(t, rel.DuplicateFiles)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithDuplicateCheck(release.DuplicateCheckEnabled).Build()
+		require.NoError(t, err)
+
+		rel, err := releaseService.Parse(releaseDir)
+		require.NoError(t, err)
+
+		require.Len(t, rel.DuplicateFiles, 2)
+
+		hardlinks := rel.DuplicateFiles[0]
+		assert.Equal(t, release.DuplicateHardlink, hardlinks.Kind)
+		assert.Equal(t, []string{
+			filepath.Join(releaseDir, "Sample", "sample.mkv"), filepath.Join(releaseDir, "test.mkv"),
+		}, hardlinks.Paths)
+
+		content := rel.DuplicateFiles[1]
+		assert.Equal(t, release.DuplicateContent, content.Kind)
+		assert.Equal(t, int64(len(big)), content.Size)
+		assert.NotZero(t, content.CRC)
+		require.Len(t, content.Files, 2)
+		assert.Equal(t, "test.r00", content.Files[0].Info.Name)
+		assert.Equal(t, "test.r01", content.Files[1].Info.Name)
+
+		assert.Len(t, rel.Report.BySeverity(release.SeverityWarning), 2)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithDuplicateCheck(release.DuplicateCheckForbidden).Build()
+		require.NoError(t, err)
+
+		rel, err := releaseService.Parse(releaseDir)
+		assert.ErrorIs(t, err, release.ErrHardlink)
+		assert.ErrorIs(t, err, release.ErrDuplicateContent)
+		require.NotNil(t, rel)
+
+		assert.Equal(t, []string{"test.mkv", "test.r01"}, rel.ForbiddenFiles.Names())
+	})
+
+	t.Run("symlinks", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		setupTestDir(t, tmpDir, map[string][]byte{
+			"Test.Release-Group/test.mkv":      []byte("main-content\n"),
+			"Test.Release-Group/Subs/test.srt": []byte("subtitle\n"),
+		})
+
+		releaseDir := filepath.Join(tmpDir, "Test.Release-Group")
+
+		if err := os.Symlink("test.mkv", filepath.Join(releaseDir, "link.mkv")); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+		require.NoError(t, os.Symlink("Subs", filepath.Join(releaseDir, "LinkedSubs")))
+
+		for _, policy := range []release.SymlinkPolicy{release.SymlinkFollow, release.SymlinkRecord} {
+			releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+				WithSymlinkPolicy(policy).WithDuplicateCheck(release.DuplicateCheckForbidden).Build()
+			require.NoError(t, err)
+
+			// a symlink shares the file of its target, but is no hardlink or duplicate
+			rel, err := releaseService.Parse(releaseDir)
+			require.NoError(t, err, policy)
+			assert.Len(t, rel.Symlinks, 2, policy)
+			assert.Empty(t, rel.DuplicateFiles, policy)
+			assert.Empty(t, rel.ForbiddenFiles, policy)
+		}
+	})
+}
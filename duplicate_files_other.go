@@ -0,0 +1,10 @@
+//go:build !unix
+
+package release
+
+import "io/fs"
+
+// fileKeyOf is not supported on this system, so hardlinks are found as files with identical content.
+func fileKeyOf(fs.FileInfo) (fileKey, bool) {
+	return fileKey{}, false
+}
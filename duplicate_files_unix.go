@@ -0,0 +1,18 @@
+//go:build unix
+
+package release
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// fileKeyOf returns the device and inode of the file.
+func fileKeyOf(fileInfo fs.FileInfo) (fileKey, bool) {
+	stat, ok := fileInfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileKey{}, false
+	}
+
+	return fileKey{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
@@ -0,0 +1,534 @@
+package release
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/f4n4t/go-dtree"
+)
+
+// EpisodeCheck defines if Parse checks a TVPack for missing and duplicate episodes (see Info.MissingEpisodes).
+type EpisodeCheck int
+
+const (
+	// EpisodeCheckDisabled disables the episode check.
+	EpisodeCheckDisabled EpisodeCheck = iota
+	// EpisodeCheckEnabled adds missing and duplicate episodes to the report as warnings.
+	EpisodeCheckEnabled
+	// EpisodeCheckStrict adds missing episodes to the report as errors and duplicate episodes to the forbidden files,
+	// so incomplete season packs are rejected by Parse.
+	EpisodeCheckStrict
+)
+
+// Episode represents a single episode in a series. Episodes of daily shows only have an AirDate and anime
+// episodes numbered across all seasons only have an AbsoluteNumber.
+type Episode struct {
+	// Season is the season number, 0 if the name has no season (e.g. E01 or Part.1).
+	Season int `json:"season"`
+	// Number is the episode number, or the disc number if Disc is set.
+	Number int `json:"number"`
+	// Disc reports whether the number is a disc of a retail pack (e.g. S01D01).
+	Disc bool `json:"disc,omitempty"`
+	// AirDate is the date of a daily show (e.g. 2024.03.04), in UTC.
+	AirDate time.Time `json:"air_date,omitzero"`
+	// AbsoluteNumber is the episode number across all seasons (e.g. Show - 1045).
+	AbsoluteNumber int         `json:"absolute_number,omitempty"`
+	Name           string      `json:"name"`
+	File           *dtree.Node `json:"-"`
+}
+
+// EpisodeNumber identifies a single episode (or disc) of a season, a daily show or an anime.
+type EpisodeNumber struct {
+	Season         int       `json:"season"`
+	Number         int       `json:"number"`
+	Disc           bool      `json:"disc,omitempty"`
+	AirDate        time.Time `json:"air_date,omitzero"`
+	AbsoluteNumber int       `json:"absolute_number,omitempty"`
+}
+
+// String returns the number in the usual S01E02 notation, a number of 0 is the whole season.
+// Dated episodes are returned as 2006-01-02 and absolute numbers with at least three digits.
+func (n EpisodeNumber) String() string {
+	prefix := "E"
+	if n.Disc {
+		prefix = "D"
+	}
+
+	switch {
+	case !n.AirDate.IsZero():
+		return n.AirDate.Format(time.DateOnly)
+	case n.AbsoluteNumber > 0:
+		return fmt.Sprintf("%03d", n.AbsoluteNumber)
+	case n.Number == 0:
+		return fmt.Sprintf("S%02d", n.Season)
+	case n.Season == 0:
+		return fmt.Sprintf("%s%02d", prefix, n.Number)
+	default:
+		return fmt.Sprintf("S%02d%s%02d", n.Season, prefix, n.Number)
+	}
+}
+
+// episodeNumber returns the number of the episode.
+func (e Episode) episodeNumber() EpisodeNumber {
+	return EpisodeNumber{
+		Season:         e.Season,
+		Number:         e.Number,
+		Disc:           e.Disc,
+		AirDate:        e.AirDate,
+		AbsoluteNumber: e.AbsoluteNumber,
+	}
+}
+
+// EpisodeGaps is the result of Info.MissingEpisodes.
+type EpisodeGaps struct {
+	// Missing holds all expected episodes that were not found, a number of 0 is a missing season.
+	Missing []EpisodeNumber `json:"missing,omitempty"`
+	// Duplicates holds the episodes that were found in more than one file, with the file of the duplicate.
+	Duplicates []Episode `json:"duplicates,omitempty"`
+}
+
+// Complete reports whether no episode is missing or duplicated.
+func (g EpisodeGaps) Complete() bool {
+	return len(g.Missing) == 0 && len(g.Duplicates) == 0
+}
+
+var (
+	// seasonEpisodePattern matches S01E01, S01E01E02, S01E01-E03, S01E01-03 and S01D01 (discs of retail packs).
+	seasonEpisodePattern = regexp.MustCompile(
+		`(?i)(?:^|[^a-z0-9])s(\d{1,4})[._ ]?([ed]\d{1,3}(?:[._]?[ed]\d{1,3}|-[ed]\d{1,3}|-\d{1,3}(?:[^0-9a-z]|$))*)`)
+	// episodeListPattern splits the episode part of seasonEpisodePattern, a leading - is a range.
+	episodeListPattern = regexp.MustCompile(`(?i)(-)?([ed])?(\d{1,3})`)
+	// altEpisodePattern matches 1x02, 1x02-03 and 1x02-1x03.
+	altEpisodePattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(\d{1,2})x(\d{2,3})(?:-(?:\d{1,2}x)?(\d{2,3}))?(?:[^0-9]|$)`)
+	// episodePattern matches episodes without a season like E01, EP01, Episode.1 and E01-E03.
+	episodePattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])e(?:p(?:isode)?)?[._ ]?(\d{1,3})(?:-e(?:p(?:isode)?)?[._ ]?(\d{1,3}))?(?:[^0-9]|$)`)
+	// airDatePattern matches the dates of daily shows like 2024.03.04 or 2024-03-04.
+	airDatePattern = regexp.MustCompile(`(?:^|[^0-9])((?:19|20)\d{2})[._ -](\d{2})[._ -](\d{2})(?:[^0-9]|$)`)
+	// absolutePattern matches absolute anime numbers like Show - 1045, Show - 045v2 and Show - 01-12.
+	absolutePattern = regexp.MustCompile(`(?i)(?:^|[ _])-[ _](\d{2,4})(?:v\d)?(?:[ _]?[-~][ _]?(\d{2,4})(?:v\d)?)?(?:[ ._\[(]|$)`)
+	// partPattern matches mini-series numbered with Part.1.
+	partPattern = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])part[._ ]?(\d{1,2})(?:[^0-9]|$)`)
+	// seasonRangePattern matches season packs with more than one season like S01-S03 or S01-03.
+	seasonRangePattern = regexp.MustCompile(`(?i)(?:^|[._ -])s(\d{1,4})-s?(\d{1,4})(?:[._ -]|$)`)
+)
+
+// getEpisodes processes a list of media files and a node, extracting and sorting episodes by their numbers.
+// If more than 1 episode has already been found in mediaFiles, the root node can be skipped, otherwise search in
+// the subfolders (rootNode). Episodes without a season get the season of the root name if it has exactly one.
+// The second return value holds all episodes found in more than one file.
+// Note: only call this function if the root node is a directory and not nil.
+// Precondition: mediaFiles and rootNode must not be nil.
+func getEpisodes(mediaFiles []*dtree.Node, rootNode *dtree.Node) ([]Episode, []Episode) {
+	var episodes []Episode
+
+	for _, nodes := range [][]*dtree.Node{mediaFiles, rootNode.Children} {
+		for _, file := range nodes {
+			if slices.Contains(PictureExtensions, file.Info.Extension) ||
+				slices.Contains(AudioExtensions, file.Info.Extension) {
+				continue
+			}
+
+			extractedEpisode := extractEpisodesFromFile(file)
+			episodes = append(episodes, extractedEpisode...)
+		}
+
+		if len(episodes) > 1 {
+			// we already found our episodes
+			break
+		}
+	}
+
+	if seasons := seasonsFromName(rootNode.Info.Name); len(seasons) == 1 {
+		for i := range episodes {
+			if episodes[i].Season == 0 && episodes[i].Number > 0 {
+				episodes[i].Season = seasons[0]
+			}
+		}
+	}
+
+	episodes, duplicates := removeDuplicateEpisodes(episodes)
+
+	sortEpisodes(episodes)
+	sortEpisodes(duplicates)
+
+	return episodes, duplicates
+}
+
+// extractEpisodesFromFile parses a Node's file name to extract episode numbers and creates corresponding Episode objects.
+func extractEpisodesFromFile(node *dtree.Node) []Episode {
+	results := make([]Episode, 0)
+
+	numbers := extractEpisodeNumbers(node.Info.Name)
+	if len(numbers) == 0 {
+		return results
+	}
+
+	mediaFile := node.GetBiggest(nil)
+	for _, number := range numbers {
+		results = append(results, Episode{
+			Season:         number.Season,
+			Number:         number.Number,
+			Disc:           number.Disc,
+			AirDate:        number.AirDate,
+			AbsoluteNumber: number.AbsoluteNumber,
+			File:           mediaFile,
+			Name:           mediaFile.Info.Name,
+		})
+	}
+
+	sortEpisodes(results)
+
+	return results
+}
+
+// extractEpisodeNumbers returns all episode numbers of a name without duplicates. The formats are tried in the order
+// S01E01, 1x01, air date, E01, absolute number and Part.1, only the numbers of the first format found are returned.
+func extractEpisodeNumbers(name string) []EpisodeNumber {
+	var (
+		numbers []EpisodeNumber
+		seen    = make(map[EpisodeNumber]struct{})
+	)
+
+	addNumber := func(n EpisodeNumber) {
+		if _, ok := seen[n]; !ok {
+			seen[n] = struct{}{}
+			numbers = append(numbers, n)
+		}
+	}
+
+	// fansub names only have absolute numbers, their tags (e.g. the CRC) must not be read as episodes
+	if an, ok := ParseAnimeName(name); ok {
+		for _, number := range an.episodeNumbers() {
+			addNumber(number)
+		}
+		return numbers
+	}
+
+	add := func(season, start, end int, disc bool) {
+		// ranges are limited, so a resolution or year is never read as the end of a range
+		if end < start || end-start > 100 {
+			end = start
+		}
+		for number := start; number <= end; number++ {
+			addNumber(EpisodeNumber{Season: season, Number: number, Disc: disc})
+		}
+	}
+
+	for _, match := range seasonEpisodePattern.FindAllStringSubmatch(name, -1) {
+		season, _ := strconv.Atoi(match[1])
+
+		var last EpisodeNumber
+		for _, part := range episodeListPattern.FindAllStringSubmatch(match[2], -1) {
+			number, _ := strconv.Atoi(part[3])
+
+			if part[1] != "" && last.Number > 0 {
+				add(season, last.Number, number, last.Disc)
+				continue
+			}
+
+			last = EpisodeNumber{Season: season, Number: number, Disc: part[2] == "d" || part[2] == "D"}
+			add(season, number, number, last.Disc)
+		}
+	}
+
+	if len(numbers) > 0 {
+		return numbers
+	}
+
+	for _, match := range altEpisodePattern.FindAllStringSubmatch(name, -1) {
+		season, _ := strconv.Atoi(match[1])
+		start, _ := strconv.Atoi(match[2])
+		end := start
+		if match[3] != "" {
+			end, _ = strconv.Atoi(match[3])
+		}
+		add(season, start, end, false)
+	}
+
+	if len(numbers) > 0 {
+		return numbers
+	}
+
+	for _, match := range airDatePattern.FindAllStringSubmatch(name, -1) {
+		if airDate, err := time.Parse(time.DateOnly, match[1]+"-"+match[2]+"-"+match[3]); err == nil {
+			addNumber(EpisodeNumber{AirDate: airDate})
+		}
+	}
+
+	if len(numbers) > 0 {
+		return numbers
+	}
+
+	for _, match := range episodePattern.FindAllStringSubmatch(name, -1) {
+		start, _ := strconv.Atoi(match[1])
+		end := start
+		if match[2] != "" {
+			end, _ = strconv.Atoi(match[2])
+		}
+		add(0, start, end, false)
+	}
+
+	if len(numbers) > 0 {
+		return numbers
+	}
+
+	for _, match := range absolutePattern.FindAllStringSubmatch(name, -1) {
+		start, _ := strconv.Atoi(match[1])
+		end := start
+		if match[2] != "" {
+			end, _ = strconv.Atoi(match[2])
+		}
+		if end < start || end-start > 2000 {
+			end = start
+		}
+		for number := start; number <= end; number++ {
+			addNumber(EpisodeNumber{AbsoluteNumber: number})
+		}
+	}
+
+	if len(numbers) > 0 {
+		return numbers
+	}
+
+	for _, match := range partPattern.FindAllStringSubmatch(name, -1) {
+		number, _ := strconv.Atoi(match[1])
+		add(0, number, number, false)
+	}
+
+	return numbers
+}
+
+// seasonsFromName returns the seasons of a release name, e.g. [1 2 3] for S01-S03 or [1] for S01E05.
+func seasonsFromName(name string) []int {
+	if m := seasonRangePattern.FindStringSubmatch(name); m != nil {
+		start, _ := strconv.Atoi(m[1])
+		end, _ := strconv.Atoi(m[2])
+
+		if start <= end && end-start <= 100 {
+			seasons := make([]int, 0, end-start+1)
+			for season := start; season <= end; season++ {
+				seasons = append(seasons, season)
+			}
+			return seasons
+		}
+	}
+
+	if season := ParseName(name).Season; season.Found() {
+		return []int{season.Int()}
+	}
+
+	return nil
+}
+
+// removeDuplicateEpisodes removes every duplicate episode from the episode slice and returns them separately.
+// The same episode found again in the same file (e.g. in the file and its folder name) is not a duplicate.
+func removeDuplicateEpisodes(episodes []Episode) ([]Episode, []Episode) {
+	files := make(map[EpisodeNumber]*dtree.Node)
+	list := []Episode{}
+
+	var duplicates []Episode
+
+	for _, episode := range episodes {
+		file, ok := files[episode.episodeNumber()]
+		if !ok {
+			files[episode.episodeNumber()] = episode.File
+			list = append(list, episode)
+			continue
+		}
+
+		if file != episode.File {
+			duplicates = append(duplicates, episode)
+		}
+	}
+
+	return list, duplicates
+}
+
+// sortEpisodes sorts the episodes by season and number, then by air date and absolute number.
+func sortEpisodes(episodes []Episode) {
+	slices.SortStableFunc(episodes, func(a, b Episode) int {
+		return cmp.Or(cmp.Compare(a.Season, b.Season), cmp.Compare(a.Number, b.Number), a.AirDate.Compare(b.AirDate),
+			cmp.Compare(a.AbsoluteNumber, b.AbsoluteNumber))
+	})
+}
+
+// isEpisodeBatch reports whether the episodes are dated or absolute episodes from more than one file,
+// e.g. a pack of a daily show or an anime batch whose name has no season.
+func isEpisodeBatch(episodes []Episode) bool {
+	files := make(map[*dtree.Node]struct{})
+
+	for _, episode := range episodes {
+		if episode.AirDate.IsZero() && episode.AbsoluteNumber == 0 {
+			return false
+		}
+		files[episode.File] = struct{}{}
+	}
+
+	return len(files) > 1
+}
+
+// MissingEpisodes compares the episodes with the range expected from the release name and returns the gaps and
+// the duplicates. If the name has episode numbers (e.g. S01E01-E10) exactly these are expected, otherwise every
+// season of the name (S01 or S01-S03) or between the first and the last season found is expected to start with
+// episode 1 and to end with the highest episode found.
+// Absolute numbers are expected from the range of the name (e.g. Show - 01-12) or between the lowest and the
+// highest number found. Daily shows don't air on fixed days, so dated episodes are only checked for duplicates.
+func (i *Info) MissingEpisodes() EpisodeGaps {
+	gaps := EpisodeGaps{Duplicates: i.DuplicateEpisodes}
+
+	var seasonEpisodes, absoluteEpisodes []Episode
+
+	for _, episode := range i.Episodes {
+		switch {
+		case episode.AbsoluteNumber > 0:
+			absoluteEpisodes = append(absoluteEpisodes, episode)
+		case episode.AirDate.IsZero():
+			seasonEpisodes = append(seasonEpisodes, episode)
+		}
+	}
+
+	gaps.Missing = append(missingSeasonEpisodes(i.Name, seasonEpisodes), missingAbsoluteEpisodes(i.Name, absoluteEpisodes)...)
+
+	return gaps
+}
+
+// missingSeasonEpisodes returns the missing episodes of the seasons, see MissingEpisodes.
+func missingSeasonEpisodes(name string, episodes []Episode) []EpisodeNumber {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	var (
+		missing  []EpisodeNumber
+		found    = make(map[EpisodeNumber]struct{})
+		last     = make(map[int]int)
+		discs    = make(map[int]bool)
+		expected = make(map[int][]EpisodeNumber)
+	)
+
+	for _, episode := range episodes {
+		found[episode.episodeNumber()] = struct{}{}
+		last[episode.Season] = max(last[episode.Season], episode.Number)
+		discs[episode.Season] = discs[episode.Season] || episode.Disc
+	}
+
+	for _, number := range extractEpisodeNumbers(name) {
+		if number.Number > 0 {
+			expected[number.Season] = append(expected[number.Season], number)
+		}
+	}
+
+	seasons := slices.Sorted(maps.Keys(expected))
+	if len(seasons) == 0 {
+		seasons = seasonsFromName(name)
+	}
+	if len(seasons) == 0 {
+		// every season between the first and the last season found is expected
+		seasons = slices.Sorted(maps.Keys(last))
+		for season := max(seasons[0], 1); season < seasons[len(seasons)-1]; season++ {
+			if _, ok := last[season]; !ok {
+				seasons = append(seasons, season)
+			}
+		}
+		slices.Sort(seasons)
+	}
+
+	for _, season := range seasons {
+		numbers, ok := expected[season]
+		if !ok {
+			if _, ok := last[season]; !ok {
+				missing = append(missing, EpisodeNumber{Season: season})
+				continue
+			}
+
+			for number := 1; number <= last[season]; number++ {
+				numbers = append(numbers, EpisodeNumber{Season: season, Number: number, Disc: discs[season]})
+			}
+		}
+
+		for _, number := range numbers {
+			if _, ok := found[number]; !ok {
+				missing = append(missing, number)
+			}
+		}
+	}
+
+	return missing
+}
+
+// missingAbsoluteEpisodes returns the missing absolute numbers, see MissingEpisodes.
+func missingAbsoluteEpisodes(name string, episodes []Episode) []EpisodeNumber {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	var (
+		missing  []EpisodeNumber
+		found    = make(map[int]struct{})
+		expected []int
+	)
+
+	first, last := episodes[0].AbsoluteNumber, episodes[0].AbsoluteNumber
+	for _, episode := range episodes {
+		found[episode.AbsoluteNumber] = struct{}{}
+		first, last = min(first, episode.AbsoluteNumber), max(last, episode.AbsoluteNumber)
+	}
+
+	for _, number := range extractEpisodeNumbers(name) {
+		if number.AbsoluteNumber > 0 {
+			expected = append(expected, number.AbsoluteNumber)
+		}
+	}
+
+	if len(expected) == 0 {
+		for number := first; number <= last; number++ {
+			expected = append(expected, number)
+		}
+	}
+
+	for _, number := range expected {
+		if _, ok := found[number]; !ok {
+			missing = append(missing, EpisodeNumber{AbsoluteNumber: number})
+		}
+	}
+
+	return missing
+}
+
+// checkEpisodes adds the missing and duplicate episodes of a TVPack or an anime batch to the report.
+func (s *Service) checkEpisodes(info *Info) {
+	gaps := info.MissingEpisodes()
+
+	severity := SeverityWarning
+	if s.episodeCheck == EpisodeCheckStrict {
+		severity = SeverityError
+	}
+
+	for _, number := range gaps.Missing {
+		episodeErr := fmt.Errorf("%w: %s", ErrMissingEpisode, number)
+		if severity == SeverityError {
+			s.log.Error().Str("name", info.Name).Err(episodeErr).Msg("")
+		} else {
+			s.log.Warn().Str("name", info.Name).Err(episodeErr).Msg("")
+		}
+		info.addFinding(CodeMissingEpisode, severity, "", episodeErr)
+	}
+
+	for _, episode := range gaps.Duplicates {
+		episodeErr := fmt.Errorf("%w: %s", ErrDuplicateEpisode, episode.episodeNumber())
+
+		if severity != SeverityError {
+			s.log.Warn().Str("name", episode.Name).Err(episodeErr).Msg("")
+			info.addFinding(CodeDuplicateEpisode, severity, episode.File.FullPath, episodeErr)
+			continue
+		}
+
+		s.log.Error().Str("name", episode.Name).Err(episodeErr).Msg("")
+		info.addForbiddenFile(CodeDuplicateEpisode, episode.File.FullPath, episode.File.Info, episodeErr)
+	}
+}
@@ -0,0 +1,208 @@
+package release_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfo_MissingEpisodes(t *testing.T) {
+	episodes := func(season int, numbers ...int) []release.Episode {
+		var list []release.Episode
+		for _, number := range numbers {
+			list = append(list, release.Episode{Season: season, Number: number})
+		}
+		return list
+	}
+
+	tests := []struct {
+		desc     string
+		name     string
+		episodes []release.Episode
+		expected []release.EpisodeNumber
+	}{
+		{
+			desc:     "complete season",
+			name:     "Show.S01.German.1080p.WEB.x264-Group",
+			episodes: episodes(1, 1, 2, 3),
+		},
+		{
+			desc:     "gap in season",
+			name:     "Show.S01.German.1080p.WEB.x264-Group",
+			episodes: episodes(1, 1, 2, 5),
+			expected: []release.EpisodeNumber{{Season: 1, Number: 3}, {Season: 1, Number: 4}},
+		},
+		{
+			desc:     "missing first episode",
+			name:     "Show.S01.German.1080p.WEB.x264-Group",
+			episodes: episodes(1, 2, 3),
+			expected: []release.EpisodeNumber{{Season: 1, Number: 1}},
+		},
+		{
+			desc:     "missing season of a season range",
+			name:     "Show.S01-S03.German.1080p.WEB.x264-Group",
+			episodes: append(episodes(1, 1, 2), episodes(3, 1, 2)...),
+			expected: []release.EpisodeNumber{{Season: 2}},
+		},
+		{
+			desc:     "missing season between the seasons found",
+			name:     "Show.COMPLETE.German.1080p.WEB.x264-Group",
+			episodes: append(episodes(1, 1), episodes(3, 1)...),
+			expected: []release.EpisodeNumber{{Season: 2}},
+		},
+		{
+			desc:     "episode range in name",
+			name:     "Show.S01E01-E04.German.1080p.WEB.x264-Group",
+			episodes: episodes(1, 1, 2, 3),
+			expected: []release.EpisodeNumber{{Season: 1, Number: 4}},
+		},
+		{
+			desc:     "parts without season",
+			name:     "Show.German.1080p.WEB.x264-Group",
+			episodes: episodes(0, 1, 3),
+			expected: []release.EpisodeNumber{{Season: 0, Number: 2}},
+		},
+		{
+			desc: "absolute numbers between the lowest and the highest number",
+			name: "Anime.Show.German.1080p.WEB.x264-Group",
+			episodes: []release.Episode{
+				{AbsoluteNumber: 13}, {AbsoluteNumber: 14}, {AbsoluteNumber: 16},
+			},
+			expected: []release.EpisodeNumber{{AbsoluteNumber: 15}},
+		},
+		{
+			desc: "absolute range in name",
+			name: "[Group] Anime Show - 01-04 [1080p]",
+			episodes: []release.Episode{
+				{AbsoluteNumber: 2}, {AbsoluteNumber: 3},
+			},
+			expected: []release.EpisodeNumber{{AbsoluteNumber: 1}, {AbsoluteNumber: 4}},
+		},
+		{
+			desc: "dated episodes have no gaps",
+			name: "Talk.Show.2024.03.04-2024.03.08.German.720p.WEB.x264-Group",
+			episodes: []release.Episode{
+				{AirDate: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+				{AirDate: time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			desc:     "missing disc",
+			name:     "Show.S01.German.COMPLETE.DVD9-Group",
+			episodes: []release.Episode{{Season: 1, Number: 1, Disc: true}, {Season: 1, Number: 3, Disc: true}},
+			expected: []release.EpisodeNumber{{Season: 1, Number: 2, Disc: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			info := release.Info{Name: tt.name, Episodes: tt.episodes}
+
+			gaps := info.MissingEpisodes()
+			assert.Equal(t, tt.expected, gaps.Missing)
+			assert.Equal(t, len(tt.expected) == 0, gaps.Complete())
+		})
+	}
+
+	assert.Equal(t, "S01E02", release.EpisodeNumber{Season: 1, Number: 2}.String())
+	assert.Equal(t, "S02", release.EpisodeNumber{Season: 2}.String())
+	assert.Equal(t, "S01D03", release.EpisodeNumber{Season: 1, Number: 3, Disc: true}.String())
+	assert.Equal(t, "2024-03-04", release.EpisodeNumber{AirDate: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)}.String())
+	assert.Equal(t, "045", release.EpisodeNumber{AbsoluteNumber: 45}.String())
+}
+
+func TestParse_EpisodeCheck(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Incomplete.S01.German.1080p.WEB.x264-Group/incomplete.s01e01.mkv":                    []byte("abcde"),
+		"Incomplete.S01.German.1080p.WEB.x264-Group/incomplete.s01e03.mkv":                    []byte("abcd"),
+		"Duplicate.S01.German.1080p.WEB.x264-Group/duplicate.s01e01.mkv":                      []byte("abcde"),
+		"Duplicate.S01.German.1080p.WEB.x264-Group/duplicate.s01e02.mkv":                      []byte("abcd"),
+		"Duplicate.S01.German.1080p.WEB.x264-Group/duplicate.s01e02.proper.mkv":               []byte("abc"),
+		"Talk.Show.2024.03.04-2024.03.06.German.720p.WEB.x264-Group/talk.show.2024.03.04.mkv": []byte("abcde"),
+		"Talk.Show.2024.03.04-2024.03.06.German.720p.WEB.x264-Group/talk.show.2024.03.05.mkv": []byte("abcd"),
+		"Talk.Show.2024.03.04-2024.03.06.German.720p.WEB.x264-Group/talk.show.2024.03.06.mkv": []byte("abc"),
+		"Anime.Show.S01.German.1080p.WEB.x264-Group/[Group]_Anime_Show_-_013_(1080p).mkv":     []byte("abcde"),
+		"Anime.Show.S01.German.1080p.WEB.x264-Group/[Group]_Anime_Show_-_014_(1080p).mkv":     []byte("abcd"),
+		"Anime.Show.S01.German.1080p.WEB.x264-Group/[Group]_Anime_Show_-_016_(1080p).mkv":     []byte("abc"),
+		"Complete.S01-S02.German.1080p.WEB.x264-Group/complete.s01e01e02.mkv":                 []byte("abcde"),
+		"Complete.S01-S02.German.1080p.WEB.x264-Group/complete.s02e01-e02.mkv":                []byte("abcd"),
+	})
+
+	builder := func(check release.EpisodeCheck) *release.Service {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithEpisodeCheck(check).Build()
+		require.NoError(t, err)
+		return releaseService
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckDisabled).Parse(filepath.Join(tmpDir, "Incomplete.S01.German.1080p.WEB.x264-Group"))
+		require.NoError(t, err)
+		assert.Empty(t, rel.Report.Findings)
+		assert.Equal(t, []release.EpisodeNumber{{Season: 1, Number: 2}}, rel.MissingEpisodes().Missing)
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckEnabled).Parse(filepath.Join(tmpDir, "Incomplete.S01.German.1080p.WEB.x264-Group"))
+		require.NoError(t, err)
+		require.Len(t, rel.Report.ByCode(release.CodeMissingEpisode), 1)
+		assert.Equal(t, release.SeverityWarning, rel.Report.Findings[0].Severity)
+	})
+
+	t.Run("strict missing episode", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckStrict).Parse(filepath.Join(tmpDir, "Incomplete.S01.German.1080p.WEB.x264-Group"))
+		assert.ErrorIs(t, err, release.ErrIncompletePack)
+		assert.ErrorIs(t, err, release.ErrMissingEpisode)
+		assert.NotErrorIs(t, err, release.ErrForbiddenFiles)
+		require.NotNil(t, rel)
+		assert.Empty(t, rel.ForbiddenFiles)
+	})
+
+	t.Run("strict duplicate episode", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckStrict).Parse(filepath.Join(tmpDir, "Duplicate.S01.German.1080p.WEB.x264-Group"))
+		assert.ErrorIs(t, err, release.ErrForbiddenFiles)
+		assert.ErrorIs(t, err, release.ErrDuplicateEpisode)
+		require.NotNil(t, rel)
+		assert.Equal(t, []string{"duplicate.s01e02.proper.mkv"}, rel.ForbiddenFiles.Names())
+		require.Len(t, rel.DuplicateEpisodes, 1)
+		assert.Equal(t, 2, rel.DuplicateEpisodes[0].Number)
+	})
+
+	t.Run("strict complete multi season pack", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckStrict).Parse(filepath.Join(tmpDir, "Complete.S01-S02.German.1080p.WEB.x264-Group"))
+		require.NoError(t, err)
+		assert.Equal(t, release.TVPack, rel.Section)
+		assert.Len(t, rel.Episodes, 4)
+		assert.True(t, rel.MissingEpisodes().Complete())
+	})
+
+	t.Run("daily show pack", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckStrict).Parse(filepath.Join(tmpDir, "Talk.Show.2024.03.04-2024.03.06.German.720p.WEB.x264-Group"))
+		require.NoError(t, err)
+		assert.Equal(t, release.TVPack, rel.Section)
+		require.Len(t, rel.Episodes, 3)
+		assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), rel.Episodes[1].AirDate)
+		assert.Equal(t, "talk.show.2024.03.05.mkv", rel.Episodes[1].Name)
+	})
+
+	t.Run("anime batch", func(t *testing.T) {
+		rel, err := builder(release.EpisodeCheckStrict).Parse(filepath.Join(tmpDir, "Anime.Show.S01.German.1080p.WEB.x264-Group"))
+		assert.ErrorIs(t, err, release.ErrMissingEpisode)
+		require.NotNil(t, rel)
+		assert.Equal(t, release.TVPack, rel.Section)
+		require.Len(t, rel.Episodes, 3)
+		assert.Equal(t, []int{13, 14, 16}, []int{
+			rel.Episodes[0].AbsoluteNumber, rel.Episodes[1].AbsoluteNumber, rel.Episodes[2].AbsoluteNumber,
+		})
+		assert.Equal(t, []release.EpisodeNumber{{AbsoluteNumber: 15}}, rel.MissingEpisodes().Missing)
+	})
+}
@@ -0,0 +1,57 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractEpisodeNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []EpisodeNumber
+	}{
+		{input: "Show.S01E02.German.1080p.WEB.x264-Group", expected: []EpisodeNumber{{Season: 1, Number: 2}}},
+		{input: "show.s01e01e02.mkv", expected: []EpisodeNumber{{Season: 1, Number: 1}, {Season: 1, Number: 2}}},
+		{input: "Show.S02E01-E03.German.1080p.WEB.x264-Group", expected: []EpisodeNumber{
+			{Season: 2, Number: 1}, {Season: 2, Number: 2}, {Season: 2, Number: 3},
+		}},
+		{input: "Show.S02E01-03.German.1080p.WEB.x264-Group", expected: []EpisodeNumber{
+			{Season: 2, Number: 1}, {Season: 2, Number: 2}, {Season: 2, Number: 3},
+		}},
+		{input: "Show.S01E01-720p.WEB.x264-Group", expected: []EpisodeNumber{{Season: 1, Number: 1}}},
+		{input: "Show.S01D02.German.DVD9-Group", expected: []EpisodeNumber{{Season: 1, Number: 2, Disc: true}}},
+		{input: "Show.1x02.German.720p.HDTV.x264-Group", expected: []EpisodeNumber{{Season: 1, Number: 2}}},
+		{input: "Show.1x02-03.German.720p.HDTV.x264-Group", expected: []EpisodeNumber{
+			{Season: 1, Number: 2}, {Season: 1, Number: 3},
+		}},
+		{input: "Show.E05.German.720p.HDTV.x264-Group", expected: []EpisodeNumber{{Number: 5}}},
+		{input: "Show.Part.2.German.720p.HDTV.x264-Group", expected: []EpisodeNumber{{Number: 2}}},
+		{input: "Talk.Show.2024.03.04.German.720p.WEB.x264-Group", expected: []EpisodeNumber{
+			{AirDate: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		}},
+		{input: "talk.show.2024-03-05.mkv", expected: []EpisodeNumber{{AirDate: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)}}},
+		{input: "Talk.Show.2024.02.30.German.720p.WEB.x264-Group", expected: nil},
+		{input: "[Group]_Anime_Show_-_1045_(1080p).mkv", expected: []EpisodeNumber{{AbsoluteNumber: 1045}}},
+		{input: "[Group] Anime Show - 045v2 [1080p].mkv", expected: []EpisodeNumber{{AbsoluteNumber: 45}}},
+		{input: "[Group] Anime Show - 01-03 [1080p]", expected: []EpisodeNumber{
+			{AbsoluteNumber: 1}, {AbsoluteNumber: 2}, {AbsoluteNumber: 3},
+		}},
+		{input: "Movie.1920x1080.DD5.1.x264-Group", expected: nil},
+		{input: "Show.S01.German.720p.HDTV.x264-Group", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractEpisodeNumbers(tt.input))
+		})
+	}
+}
+
+func TestSeasonsFromName(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, seasonsFromName("Show.S01-S03.German.1080p.BluRay.x264-Group"))
+	assert.Equal(t, []int{2, 3}, seasonsFromName("Show.S02-03.German.1080p.BluRay.x264-Group"))
+	assert.Equal(t, []int{4}, seasonsFromName("Show.S04.German.1080p.BluRay.x264-Group"))
+	assert.Nil(t, seasonsFromName("Movie.2020.German.1080p.BluRay.x264-Group"))
+}
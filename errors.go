@@ -9,7 +9,7 @@ var (
 	// ErrFileNotFound is the error returned when a specified file cannot be located.
 	ErrFileNotFound = errors.New("file not found")
 
-	// ErrForbiddenFiles is the error that Parse will return on forbidden files.
+	// ErrForbiddenFiles is matched by the error that Parse will return on forbidden files (a *ReportError).
 	ErrForbiddenFiles = errors.New("forbidden files")
 
 	// ErrForbiddenExtension is returned when a file has a prohibited or unsupported extension.
@@ -20,4 +20,35 @@ var (
 
 	// ErrEmptyFile is the error returned when a file is empty.
 	ErrEmptyFile = errors.New("empty file")
+
+	// ErrNoContent is the error returned when the content of a file is requested from a release parsed by ParseListing.
+	ErrNoContent = errors.New("no file content available")
+
+	// ErrForbiddenSymlink is the error of a symlink that is rejected by SymlinkReject.
+	ErrForbiddenSymlink = errors.New("forbidden symlink")
+
+	// ErrBrokenSymlink is the error of a symlink whose target does not exist.
+	ErrBrokenSymlink = errors.New("broken symlink")
+
+	// ErrSymlinkLoop is the error of a followed symlink that links to one of its parent folders.
+	ErrSymlinkLoop = errors.New("symlink loop")
+
+	// ErrSpecialFile is the error of a socket, named pipe or device file.
+	ErrSpecialFile = errors.New("special file")
+
+	// ErrHardlink is the error of a file that is a hardlink of another file in the release.
+	ErrHardlink = errors.New("hardlink")
+
+	// ErrDuplicateContent is the error of a file with the same content as another file in the release.
+	ErrDuplicateContent = errors.New("duplicate content")
+
+	// ErrIncompletePack is matched by the error that Parse will return on missing episodes without forbidden files
+	// (see EpisodeCheckStrict).
+	ErrIncompletePack = errors.New("incomplete pack")
+
+	// ErrMissingEpisode is the error of an episode or season that is missing in a TVPack.
+	ErrMissingEpisode = errors.New("missing episode")
+
+	// ErrDuplicateEpisode is the error of a file with an episode that was already found in another file.
+	ErrDuplicateEpisode = errors.New("duplicate episode")
 )
@@ -0,0 +1,220 @@
+package release
+
+import (
+	"cmp"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/f4n4t/go-dtree"
+)
+
+// FixType is the kind of fix release, e.g. a DIRFIX for a release with a wrong name.
+// Fixes are released by the group of the parent release and only contain the fixed files.
+type FixType string
+
+const (
+	// NoFix is the FixType of all releases that are not a fix.
+	NoFix FixType = ""
+	// DirFix fixes the name of the parent release, it only contains an NFO.
+	DirFix FixType = "DIRFIX"
+	// NFOFix replaces the NFO of the parent release.
+	NFOFix FixType = "NFOFIX"
+	// SampleFix adds a missing or broken sample.
+	SampleFix FixType = "SAMPLEFIX"
+	// ProofFix adds a missing proof picture.
+	ProofFix FixType = "PROOFFIX"
+	// SubFix adds missing or broken subtitles.
+	SubFix FixType = "SUBFIX"
+)
+
+// Rule IDs of the fix rulesets, see FixRuleset.
+const (
+	RuleFixContent = "fix-content"
+	RuleFixNFO     = "fix-nfo"
+)
+
+var (
+	// ErrFixContent is the error of a file that is not allowed in the type of fix.
+	ErrFixContent = errors.New("file not allowed in fix")
+
+	// ErrMissingNFO is the error of a DIRFIX or NFOFIX without an NFO.
+	ErrMissingNFO = errors.New("missing nfo")
+)
+
+// fixTagPattern matches the fix tags in a release name.
+var fixTagPattern = tokenPattern(`dirfix|nfofix|samplefix|prooffix|subfix`)
+
+// fixContent holds the pattern for the names of the files every fix type may contain.
+var fixContent = map[FixType]*regexp.Regexp{
+	DirFix:    regexp.MustCompile(`(?i)\.nfo$`),
+	NFOFix:    regexp.MustCompile(`(?i)\.nfo$`),
+	SampleFix: regexp.MustCompile(`(?i)\.(nfo|sfv|avi|mkv|mp4|m4v|m2ts|wmv|vob|mpe?g)$`),
+	ProofFix:  regexp.MustCompile(`(?i)\.(nfo|sfv|jpe?g|png|gif|rar|r\d{2})$`),
+	SubFix:    regexp.MustCompile(`(?i)\.(nfo|sfv|srt|sub|idx|ass|ssa|sup|rar|r\d{2})$`),
+}
+
+// ParseFixType returns the FixType of the first fix tag in the release name, NoFix if the name has none.
+func ParseFixType(name string) FixType {
+	if m := fixTagPattern.FindStringSubmatch(name); m != nil {
+		return FixType(strings.ToUpper(m[1]))
+	}
+	return NoFix
+}
+
+// StripFixTag removes all fix tags with their leading separator from the release name,
+// e.g. "Movie.2020.DIRFIX.1080p.BluRay.x264-GRP" becomes "Movie.2020.1080p.BluRay.x264-GRP".
+func StripFixTag(name string) string {
+	for {
+		m := fixTagPattern.FindStringSubmatchIndex(name)
+		if m == nil {
+			return name
+		}
+
+		start, end := m[2], m[3]
+		if start > 0 {
+			start--
+		} else if end < len(name) {
+			end++
+		}
+
+		name = name[:start] + name[end:]
+	}
+}
+
+// FixRuleset returns the builtin ruleset for the content of the fix type, nil for NoFix.
+// Every fix type only allows its own files besides an NFO and sfv, a DIRFIX and NFOFIX have to contain an NFO.
+// Parse adds the files that violate it to the forbidden files.
+func FixRuleset(fix FixType) *Ruleset {
+	allowed, ok := fixContent[fix]
+	if !ok {
+		return nil
+	}
+
+	rs := &Ruleset{
+		Name: strings.ToLower(string(fix)),
+		Rules: []Rule{
+			{
+				ID:          RuleFixContent,
+				Description: "a " + string(fix) + " must only contain the fixed files",
+				Severity:    SeverityError,
+				Forbid:      &FileCondition{ExceptName: allowed.String(), exceptNameRegex: allowed},
+				err:         ErrFixContent,
+			},
+		},
+	}
+
+	if fix == DirFix || fix == NFOFix {
+		one := 1
+
+		rs.Rules = append(rs.Rules, Rule{
+			ID:          RuleFixNFO,
+			Description: "a " + string(fix) + " must contain an nfo",
+			Severity:    SeverityError,
+			Require:     &Condition{Extensions: map[string]CountRange{".nfo": {Min: &one}}},
+			err:         ErrMissingNFO,
+		})
+	}
+
+	return rs
+}
+
+// ResolveFixParent returns the name of the release the fix belongs to. The fix tag is stripped from the name and
+// the result is searched in the fix index (see WithFixIndex) and then in the pre information.
+// Releases of the index have to be from the same group and have the exact name. A DIRFIX is often released for a
+// wrong name, so it also accepts the only release of the group with the same title, year and episode or the only
+// one of them with the same quality.
+func (s *Service) ResolveFixParent(name string) (string, bool) {
+	stripped := StripFixTag(name)
+	if stripped == name {
+		return "", false
+	}
+
+	if s.fixIndex != nil {
+		if parent := s.lookupFixParent(stripped, ParseFixType(name)); parent != nil {
+			return parent.Name, true
+		}
+	}
+
+	if !s.skipPre {
+		if pre := s.searchPre(stripped); pre != nil {
+			return pre.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// lookupFixParent searches the fix index for the parent release with the stripped name.
+func (s *Service) lookupFixParent(stripped string, fix FixType) *Info {
+	rlsName := ParseName(stripped)
+
+	candidate := &Info{
+		Name:          stripped,
+		ReleaseName:   rlsName,
+		Group:         rlsName.Group.Value,
+		TagResolution: ParseResolution(stripped),
+		ProductTitle:  cleanTitle(stripped),
+		ProductYear:   rlsName.Year.Int(),
+	}
+
+	var sameGroup, matchingQuality []*Info
+
+	// the parent has the name of the candidate, so it must not be skipped like in Lookup
+	for _, indexed := range s.fixIndex.lookup(candidate, DupeExact, false) {
+		if indexed.FixType != NoFix || !strings.EqualFold(indexed.Group, candidate.Group) {
+			continue
+		}
+
+		if strings.EqualFold(indexed.Name, stripped) {
+			return indexed
+		}
+
+		sameGroup = append(sameGroup, indexed)
+		if sameQuality(indexed, candidate) {
+			matchingQuality = append(matchingQuality, indexed)
+		}
+	}
+
+	switch {
+	case fix != DirFix:
+		return nil
+	case len(sameGroup) == 1:
+		return sameGroup[0]
+	case len(matchingQuality) == 1:
+		return matchingQuality[0]
+	default:
+		return nil
+	}
+}
+
+// checkFixContent checks the release against the FixRuleset of its fix type. Files that are not allowed are
+// added to the forbidden files, a missing NFO to the report.
+func (s *Service) checkFixContent(info *Info) {
+	ruleset := FixRuleset(info.FixType)
+	if ruleset == nil {
+		return
+	}
+
+	nodes := make(map[string]*dtree.Node)
+	walkNodes(info.Root, func(node *dtree.Node) {
+		nodes[node.FullPath] = node
+	})
+
+	for _, violation := range ruleset.Evaluate(info) {
+		node, ok := nodes[violation.Path]
+		if !ok {
+			s.log.Error().Str("name", info.Name).Err(violation.Err).Msg(violation.Description)
+			info.addFinding(FindingCode(violation.RuleID), violation.Severity, "", violation.Err)
+			continue
+		}
+
+		s.log.Error().Str("name", node.Info.Name).Err(violation.Err).Msg(violation.Description)
+		info.addForbiddenFile(FindingCode(violation.RuleID), node.FullPath, node.Info, violation.Err)
+	}
+}
+
+// fixSectionName returns the name used for the section of the fix, the parent release if it was resolved.
+func (rel *Info) fixSectionName() string {
+	return cmp.Or(rel.FixParent, StripFixTag(rel.Name))
+}
@@ -0,0 +1,205 @@
+package release_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFixType(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected release.FixType
+		stripped string
+	}{
+		{
+			name:     "Movie.Title.2020.German.DIRFIX.1080p.BluRay.x264-Group",
+			expected: release.DirFix,
+			stripped: "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+		},
+		{
+			name:     "Movie.Title.2020.German.1080p.BluRay.x264.NFOFiX-Group",
+			expected: release.NFOFix,
+			stripped: "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+		},
+		{
+			name:     "Show.S01E02.German.SUBFIX.720p.HDTV.x264-Group",
+			expected: release.SubFix,
+			stripped: "Show.S01E02.German.720p.HDTV.x264-Group",
+		},
+		{
+			name:     "Movie.Title.2020.SAMPLEFIX.PROOFFIX.1080p.BluRay.x264-Group",
+			expected: release.SampleFix,
+			stripped: "Movie.Title.2020.1080p.BluRay.x264-Group",
+		},
+		{
+			name:     "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+			expected: release.NoFix,
+			stripped: "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+		},
+		{
+			name:     "Dirfixer.2020.German.1080p.BluRay.x264-Group",
+			expected: release.NoFix,
+			stripped: "Dirfixer.2020.German.1080p.BluRay.x264-Group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, release.ParseFixType(tt.name))
+			assert.Equal(t, tt.stripped, release.StripFixTag(tt.name))
+		})
+	}
+}
+
+func TestService_ResolveFixParent(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	newRelease := func(name string, resolution release.Resolution) *release.Info {
+		rn := release.ParseName(name)
+		return &release.Info{
+			Name:          name,
+			ReleaseName:   rn,
+			Group:         rn.Group.Value,
+			ProductTitle:  "Movie Title",
+			ProductYear:   rn.Year.Int(),
+			TagResolution: resolution,
+		}
+	}
+
+	index := release.NewDupeIndex(0)
+	index.Add(
+		newRelease("Movie.Title.2020.German.1080p.BluRay.x264-Group", release.FHD),
+		newRelease("Movie.Title.2020.German.720p.BluRay.x264-Group", release.HD),
+		newRelease("Movie.Title.2020.German.1080p.BluRay.x264-Other", release.FHD),
+	)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithFixIndex(index).Build()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Movie.Title.2020.German.NFOFIX.1080p.BluRay.x264-Group", expected: "Movie.Title.2020.German.1080p.BluRay.x264-Group"},
+		{name: "Movie.Title.2020.German.DIRFIX.1080p.BluRay.DTS.x264-Group", expected: "Movie.Title.2020.German.1080p.BluRay.x264-Group"},
+		{name: "Movie.Title.2020.German.DIRFIX.2160p.WEB.h265-Group", expected: ""},
+		{name: "Movie.Title.2020.German.NFOFIX.1080p.BluRay.DTS.x264-Group", expected: ""},
+		{name: "Movie.Title.2020.German.NFOFIX.1080p.BluRay.x264-Unknown", expected: ""},
+		{name: "Other.Movie.2020.German.NFOFIX.1080p.BluRay.x264-Group", expected: ""},
+		{name: "Movie.Title.2020.German.1080p.BluRay.x264-Group", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, ok := releaseService.ResolveFixParent(tt.name)
+			assert.Equal(t, tt.expected, parent)
+			assert.Equal(t, tt.expected != "", ok)
+		})
+	}
+}
+
+func TestParse_Fixes(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tests := []struct {
+		name          string
+		testFiles     map[string][]byte
+		wantFixType   release.FixType
+		wantParent    string
+		wantForbidden []string
+		wantCodes     []release.FindingCode
+	}{
+		{
+			name: "Movie.Title.2020.German.NFOFIX.1080p.BluRay.x264-Group",
+			testFiles: map[string][]byte{
+				"group-movie.title.nfofix.nfo": []byte("nfo\n"),
+			},
+			wantFixType: release.NFOFix,
+			wantParent:  "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+		},
+		{
+			name: "Movie.Title.2020.German.SUBFIX.1080p.BluRay.x264-Group",
+			testFiles: map[string][]byte{
+				"group-movie.title.subfix.nfo":      []byte("nfo\n"),
+				"Subs/group-movie.title.subs.rar":   []byte("rar"),
+				"Subs/group-movie.title.subs.sfv":   []byte("group-movie.title.subs.rar 00000000\n"),
+				"Subs/group-movie.title.german.srt": []byte("srt"),
+			},
+			wantFixType: release.SubFix,
+			wantParent:  "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+		},
+		{
+			name: "Movie.Title.2020.German.DIRFIX.1080p.BluRay.x264-Group",
+			testFiles: map[string][]byte{
+				"group-movie.title.dirfix.nfo": []byte("nfo\n"),
+				"group-movie.title.mkv":        []byte("mkv"),
+			},
+			wantFixType:   release.DirFix,
+			wantParent:    "Movie.Title.2020.German.1080p.BluRay.x264-Group",
+			wantForbidden: []string{"group-movie.title.mkv"},
+			wantCodes:     []release.FindingCode{release.CodeFixContent},
+		},
+		{
+			name: "Movie.Title.2020.German.NFOFIX.720p.BluRay.x264-Group",
+			testFiles: map[string][]byte{
+				"Proof/group-movie.title.proof.jpg": []byte("jpg"),
+			},
+			wantFixType:   release.NFOFix,
+			wantForbidden: []string{"group-movie.title.proof.jpg"},
+			wantCodes:     []release.FindingCode{release.CodeFixContent, release.CodeFixNFO},
+		},
+	}
+
+	index := release.NewDupeIndex(0)
+
+	parentService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+	parentDir := t.TempDir()
+	setupTestDir(t, parentDir, map[string][]byte{
+		"Movie.Title.2020.German.1080p.BluRay.x264-Group/group-movie.title.nfo": []byte("nfo\n"),
+		"Movie.Title.2020.German.1080p.BluRay.x264-Group/group-movie.title.mkv": []byte("mkv"),
+	})
+	parent, err := parentService.Parse(filepath.Join(parentDir, "Movie.Title.2020.German.1080p.BluRay.x264-Group"))
+	require.NoError(t, err)
+	assert.Equal(t, release.NoFix, parent.FixType)
+	index.Add(parent)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).WithFixIndex(index).Build()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			testFiles := make(map[string][]byte, len(tt.testFiles))
+			for name, content := range tt.testFiles {
+				testFiles[filepath.Join(tt.name, name)] = content
+			}
+			setupTestDir(t, tmpDir, testFiles)
+
+			rel, err := releaseService.Parse(filepath.Join(tmpDir, tt.name))
+			if len(tt.wantCodes) > 0 {
+				require.ErrorIs(t, err, release.ErrForbiddenFiles)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantFixType, rel.FixType)
+			assert.Equal(t, tt.wantParent, rel.FixParent)
+			assert.Equal(t, release.Movies, rel.Section)
+			assert.ElementsMatch(t, tt.wantCodes, findingCodes(rel.Report.Findings))
+
+			var forbidden []string
+			for _, file := range rel.ForbiddenFiles {
+				forbidden = append(forbidden, filepath.Base(file.FullPath))
+			}
+			assert.Equal(t, tt.wantForbidden, forbidden)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ReleaseIgnoreFile is the name of the ignore file that is loaded from the release root by Parse.
+// The file itself is always ignored.
+const ReleaseIgnoreFile = ".releaseignore"
+
+// IgnoreMatcher matches paths against gitignore-style patterns:
+//   - a pattern without a slash matches the name at any level, otherwise it is relative to the release root
+//   - * and ? don't match a slash, ** matches any number of folders (e.g. **/Sample/*.mkv or Subs/**)
+//   - a trailing slash only matches folders and a leading ! includes a previously ignored path again
+//   - the last matching pattern wins, empty lines and lines starting with # are skipped
+//
+// Like with git, files inside an ignored folder can't be included again, because the folder is not walked.
+type IgnoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// ignorePattern is a single compiled pattern of an IgnoreMatcher.
+type ignorePattern struct {
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// NewIgnoreMatcher compiles the patterns in the given order. Parse always matches case-insensitive.
+func NewIgnoreMatcher(patterns []string, ignoreCase bool) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{}
+
+	for _, p := range patterns {
+		pattern, ok, err := compileIgnorePattern(p, ignoreCase)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", p, err)
+		}
+		if ok {
+			m.patterns = append(m.patterns, pattern)
+		}
+	}
+
+	return m, nil
+}
+
+// ParseIgnoreFile returns the patterns of an ignore file, one pattern per line.
+func ParseIgnoreFile(data []byte) []string {
+	var patterns []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		patterns = append(patterns, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+
+	return patterns
+}
+
+// LoadIgnoreFile reads the patterns of an ignore file from the local disk.
+func LoadIgnoreFile(filePath string) ([]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read ignore file: %w", err)
+	}
+
+	return ParseIgnoreFile(data), nil
+}
+
+// Empty reports whether the matcher has no patterns.
+func (m *IgnoreMatcher) Empty() bool {
+	return m == nil || len(m.patterns) == 0
+}
+
+// Match reports whether the slash separated path relative to the release root is ignored.
+func (m *IgnoreMatcher) Match(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+
+	relPath = strings.Trim(relPath, "/")
+	ignored := false
+
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.regex.MatchString(relPath) {
+			ignored = !p.negate
+		}
+	}
+
+	return ignored
+}
+
+// compileIgnorePattern converts a single gitignore-style pattern into a regex.
+// Comments and empty lines return false.
+func compileIgnorePattern(pattern string, ignoreCase bool) (ignorePattern, bool, error) {
+	var p ignorePattern
+
+	pattern = trimIgnoreSpaces(pattern)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return p, false, nil
+	}
+
+	if strings.HasPrefix(pattern, "!") {
+		p.negate = true
+		pattern = pattern[1:]
+	} else if strings.HasPrefix(pattern, `\!`) || strings.HasPrefix(pattern, `\#`) {
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		p.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	if pattern == "" {
+		return p, false, nil
+	}
+
+	// a slash at the beginning or in the middle anchors the pattern to the release root
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+
+	if ignoreCase {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/") && (i == 0 || pattern[i-1] == '/'):
+			// any number of folders
+			b.WriteString("(?:.*/)?")
+			i += 2
+
+		case strings.HasPrefix(pattern[i:], "**") && i+2 == len(pattern) && (i == 0 || pattern[i-1] == '/'):
+			// everything inside
+			b.WriteString(".*")
+			i++
+
+		case c == '*':
+			b.WriteString("[^/]*")
+
+		case c == '?':
+			b.WriteString("[^/]")
+
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == 0 {
+				// a ] directly after the [ is part of the class
+				if next := strings.IndexByte(pattern[i+2:], ']'); next >= 0 {
+					end = next + 1
+				} else {
+					end = -1
+				}
+			}
+			if end < 0 {
+				return p, false, filepath.ErrBadPattern
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^") {
+				class = "^" + class[1:]
+			}
+
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+
+	regex, err := regexp.Compile(b.String())
+	if err != nil {
+		return p, false, fmt.Errorf("%w: %w", filepath.ErrBadPattern, err)
+	}
+
+	p.regex = regex
+
+	return p, true, nil
+}
+
+// trimIgnoreSpaces removes trailing spaces that are not escaped with a backslash.
+func trimIgnoreSpaces(pattern string) string {
+	trimmed := strings.TrimRight(pattern, " ")
+	if strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(pattern) {
+		return trimmed[:len(trimmed)-1] + " "
+	}
+	return trimmed
+}
@@ -0,0 +1,176 @@
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	tests := []struct {
+		desc       string
+		path       string
+		isDir      bool
+		patterns   []string
+		ignoreCase bool
+		expected   bool
+	}{
+		{
+			desc:       "skip sample (ignore case)",
+			path:       "Sample",
+			isDir:      true,
+			patterns:   []string{"sample"},
+			ignoreCase: true,
+			expected:   true,
+		},
+		{
+			desc:     "skip sample, case sensitive",
+			path:     "sample",
+			isDir:    true,
+			patterns: []string{"Sample"},
+			expected: false,
+		},
+		{
+			desc:     "skip sample (pattern)",
+			path:     "Sample",
+			isDir:    true,
+			patterns: []string{"[sS]ample"},
+			expected: true,
+		},
+		{
+			desc:       "skip test.par2",
+			path:       "test.PAR2",
+			patterns:   []string{"test.par2"},
+			ignoreCase: true,
+			expected:   true,
+		},
+		{
+			desc:     "name matches at any level",
+			path:     "CD1/Sample/sample.mkv",
+			patterns: []string{"*.mkv"},
+			expected: true,
+		},
+		{
+			desc:     "star does not match a slash",
+			path:     "Subs/sub.rar",
+			patterns: []string{"Subs*.rar"},
+			expected: false,
+		},
+		{
+			desc:     "double star matches any folder",
+			path:     "CD1/Sample/sample.mkv",
+			patterns: []string{"**/Sample/*.mkv"},
+			expected: true,
+		},
+		{
+			desc:     "double star matches no folder",
+			path:     "Sample/sample.mkv",
+			patterns: []string{"**/Sample/*.mkv"},
+			expected: true,
+		},
+		{
+			desc:     "double star at the end",
+			path:     "Subs/idx/subs.idx",
+			patterns: []string{"Subs/**"},
+			expected: true,
+		},
+		{
+			desc:     "slash anchors the pattern",
+			path:     "CD1/Subs",
+			isDir:    true,
+			patterns: []string{"/Subs"},
+			expected: false,
+		},
+		{
+			desc:     "negation",
+			path:     "keep.nfo",
+			patterns: []string{"*.nfo", "!keep.nfo"},
+			expected: false,
+		},
+		{
+			desc:     "last pattern wins",
+			path:     "keep.nfo",
+			patterns: []string{"!keep.nfo", "*.nfo"},
+			expected: true,
+		},
+		{
+			desc:     "directory only pattern on a file",
+			path:     "Sample",
+			patterns: []string{"Sample/"},
+			expected: false,
+		},
+		{
+			desc:     "directory only pattern on a folder",
+			path:     "Sample",
+			isDir:    true,
+			patterns: []string{"Sample/"},
+			expected: true,
+		},
+		{
+			desc:     "comments and escapes",
+			path:     "#file",
+			patterns: []string{"# comment", "", `\#file`},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			matcher, err := release.NewIgnoreMatcher(tt.patterns, tt.ignoreCase)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, matcher.Match(tt.path, tt.isDir))
+		})
+	}
+
+	t.Run("bad pattern", func(t *testing.T) {
+		_, err := release.NewIgnoreMatcher([]string{"[sSample"}, true)
+		assert.ErrorIs(t, err, filepath.ErrBadPattern)
+	})
+}
+
+func TestParse_IgnoreFiles(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Test.Release-Group/test.mkv":                     []byte("content\n"),
+		"Test.Release-Group/test.nfo":                     []byte("nfo\n"),
+		"Test.Release-Group/keep.nfo":                     []byte("nfo\n"),
+		"Test.Release-Group/Sample/sample.mkv":            []byte("sample\n"),
+		"Test.Release-Group/Sample/sample.nfo":            []byte("nfo\n"),
+		"Test.Release-Group/Sample/sample.jpg":            []byte("jpg\n"),
+		"Test.Release-Group/" + release.ReleaseIgnoreFile: []byte("# release patterns\n*.nfo\n!keep.nfo\n"),
+	})
+
+	ignoreFile := filepath.Join(t.TempDir(), "ignore")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("**/sample/*.MKV\n"), 0666))
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+		WithIgnoreFile(ignoreFile).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse(filepath.Join(tmpDir, "Test.Release-Group"))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{".mkv": 1, ".nfo": 1, ".jpg": 1}, rel.Extensions)
+	assert.Equal(t, "keep.nfo", rel.NFO.Name)
+
+	_, err = rel.Root.GetFile("sample.mkv")
+	assert.Error(t, err)
+
+	t.Run("missing ignore file", func(t *testing.T) {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithIgnoreFile(filepath.Join(t.TempDir(), "missing")).Build()
+		require.NoError(t, err)
+
+		_, err = releaseService.Parse(filepath.Join(tmpDir, "Test.Release-Group"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
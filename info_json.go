@@ -0,0 +1,267 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/f4n4t/go-dtree"
+)
+
+// fullInfo is the lossless JSON representation of Info used by MarshalFull and LoadInfo.
+// The nodes outside the tree are stored by their FullPath and linked again on load.
+type fullInfo struct {
+	*infoAlias
+	Root           *dtree.Node         `json:"root"`
+	BiggestFile    string              `json:"biggest_file,omitempty"`
+	MediaFiles     []string            `json:"media_files,omitempty"`
+	Episodes       []fullEpisode       `json:"episodes"`
+	Duplicates     []fullEpisode       `json:"duplicate_episodes,omitempty"`
+	ForbiddenFiles []fullForbiddenFile `json:"forbidden_files,omitempty"`
+	MediaInfo      *MediaInfo          `json:"mediainfo,omitempty"`
+	MediaInfoJSON  []byte              `json:"mediainfo_json,omitempty"`
+	PreInfo        *Pre                `json:"pre,omitempty"`
+	NFO            *fullNFO            `json:"nfo,omitempty"`
+}
+
+// infoAlias has the fields of Info without its methods.
+type infoAlias Info
+
+type fullEpisode struct {
+	Episode
+	File string `json:"file,omitempty"`
+}
+
+type fullForbiddenFile struct {
+	FullPath  string          `json:"path"`
+	Info      *dtree.FileInfo `json:"info"`
+	Error     string          `json:"error"`
+	Violation *Violation      `json:"violation,omitempty"`
+}
+
+type fullNFO struct {
+	Name string `json:"name"`
+	// Content is encoded as base64.
+	Content []byte `json:"content"`
+}
+
+// restoredErrors are the sentinel errors of the forbidden files and of the findings of Parse and the checks,
+// they are restored by LoadInfo. All other errors (e.g. of the file system) only keep their message.
+var restoredErrors = []error{
+	ErrEmptyFolder, ErrEmptyFile, ErrForbiddenCharacters, ErrForbiddenExtension, ErrRuleViolation,
+	ErrForbiddenSymlink, ErrBrokenSymlink, ErrSymlinkLoop, ErrSpecialFile, ErrHardlink, ErrDuplicateContent,
+	ErrMissingEpisode, ErrDuplicateEpisode, ErrFixContent, ErrMissingNFO, ErrForbiddenFiles, ErrIncompletePack,
+	ErrFileNotFound, ErrSfvValidationFailed, ErrEmptySfv, ErrInvalidSfv, ErrZipValidationFailed, ErrNoFileCountInDiz,
+	ErrNoArchiveInZip, ErrSrrValidationFailed, ErrNoSRRFile, ErrCRCValidationFailed, ErrDiscValidationFailed,
+	ErrMissingDisc, ErrEmptyDisc, ErrMissingSfv, ErrUnlistedFile, ErrDiscMismatch,
+}
+
+// MarshalFull returns the JSON encoding of the release including the tree, the forbidden files and the report (the
+// errors as strings), mediainfo, pre and NFO. The default JSON encoding of Info only contains the parsed metadata.
+func (rel *Info) MarshalFull() ([]byte, error) {
+	full := fullInfo{
+		infoAlias:     (*infoAlias)(rel),
+		Root:          rel.Root,
+		MediaInfo:     rel.MediaInfo,
+		MediaInfoJSON: rel.MediaInfoJSON,
+		PreInfo:       rel.PreInfo,
+	}
+
+	if rel.BiggestFile != nil {
+		full.BiggestFile = rel.BiggestFile.FullPath
+	}
+
+	for _, node := range rel.MediaFiles {
+		full.MediaFiles = append(full.MediaFiles, node.FullPath)
+	}
+
+	full.Episodes = fullEpisodes(rel.Episodes)
+	full.Duplicates = fullEpisodes(rel.DuplicateEpisodes)
+
+	for _, f := range rel.ForbiddenFiles {
+		ff := fullForbiddenFile{FullPath: f.FullPath, Info: f.Info}
+		if f.Error != nil {
+			ff.Error = f.Error.Error()
+		}
+		var violation Violation
+		if errors.As(f.Error, &violation) {
+			ff.Violation = &violation
+		}
+		full.ForbiddenFiles = append(full.ForbiddenFiles, ff)
+	}
+
+	if rel.NFO != nil {
+		full.NFO = &fullNFO{Name: rel.NFO.Name, Content: rel.NFO.Content}
+	}
+
+	return json.Marshal(full)
+}
+
+// LoadInfo restores a release encoded with MarshalFull without accessing the disk. The nodes are linked
+// into the tree again, so Root.GetFile, MediaFiles.GetByExtensions and the like work as after Parse.
+// File contents (e.g. for CheckSFV) are read from the local disk. The errors of the forbidden files and the
+// findings match their sentinel errors with errors.Is again, other errors only keep their message.
+func LoadInfo(data []byte) (*Info, error) {
+	rel := &Info{}
+	full := fullInfo{infoAlias: (*infoAlias)(rel)}
+
+	if err := json.Unmarshal(data, &full); err != nil {
+		return nil, fmt.Errorf("unmarshal info: %w", err)
+	}
+
+	rel.Root = full.Root
+	rel.MediaInfo = full.MediaInfo
+	rel.MediaInfoJSON = full.MediaInfoJSON
+	rel.PreInfo = full.PreInfo
+
+	if full.NFO != nil {
+		rel.NFO = &NFOFile{Name: full.NFO.Name, Content: full.NFO.Content}
+	}
+
+	nodes := make(map[string]*dtree.Node)
+	if rel.Root != nil {
+		linkNodes(rel.Root, nil, nodes)
+	}
+
+	lookup := func(fullPath string) (*dtree.Node, error) {
+		node, ok := nodes[fullPath]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, fullPath)
+		}
+		return node, nil
+	}
+
+	var err error
+
+	if full.BiggestFile != "" {
+		if rel.BiggestFile, err = lookup(full.BiggestFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fullPath := range full.MediaFiles {
+		node, err := lookup(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		rel.MediaFiles = append(rel.MediaFiles, node)
+	}
+
+	if rel.Episodes, err = loadEpisodes(full.Episodes, lookup); err != nil {
+		return nil, err
+	}
+	if rel.DuplicateEpisodes, err = loadEpisodes(full.Duplicates, lookup); err != nil {
+		return nil, err
+	}
+
+	for i, disc := range rel.Discs {
+		node, err := lookup(disc.Path)
+		if err != nil {
+			return nil, err
+		}
+		rel.Discs[i] = newDisc(disc.Number, node)
+	}
+
+	for i, group := range rel.DuplicateFiles {
+		rel.DuplicateFiles[i].Files = nil
+		for _, fullPath := range group.Paths {
+			node, err := lookup(fullPath)
+			if err != nil {
+				return nil, err
+			}
+			rel.DuplicateFiles[i].Files = append(rel.DuplicateFiles[i].Files, node)
+		}
+	}
+
+	for _, ff := range full.ForbiddenFiles {
+		rel.ForbiddenFiles = append(rel.ForbiddenFiles, ForbiddenFile{
+			FullPath: ff.FullPath,
+			Info:     ff.Info,
+			Error:    restoreForbiddenError(ff),
+		})
+	}
+
+	if rel.Report != nil {
+		for i := range rel.Report.Findings {
+			rel.Report.Findings[i].Err = restoreError(rel.Report.Findings[i].Message)
+		}
+	}
+
+	return rel, nil
+}
+
+// linkNodes sets the parent of every node and collects all nodes by their FullPath.
+func linkNodes(node, parent *dtree.Node, nodes map[string]*dtree.Node) {
+	node.Parent = parent
+	nodes[node.FullPath] = node
+
+	for _, child := range node.Children {
+		linkNodes(child, node, nodes)
+	}
+}
+
+// fullEpisodes stores the files of the episodes by their FullPath.
+func fullEpisodes(episodes []Episode) []fullEpisode {
+	var full []fullEpisode
+
+	for _, episode := range episodes {
+		fe := fullEpisode{Episode: episode}
+		if episode.File != nil {
+			fe.File = episode.File.FullPath
+		}
+		full = append(full, fe)
+	}
+
+	return full
+}
+
+// loadEpisodes links the files of the episodes with the lookup function.
+func loadEpisodes(full []fullEpisode, lookup func(fullPath string) (*dtree.Node, error)) ([]Episode, error) {
+	var (
+		episodes []Episode
+		err      error
+	)
+
+	for _, fe := range full {
+		if fe.File != "" {
+			if fe.Episode.File, err = lookup(fe.File); err != nil {
+				return nil, err
+			}
+		}
+		episodes = append(episodes, fe.Episode)
+	}
+
+	return episodes, nil
+}
+
+// restoreForbiddenError returns the violation or sentinel error of the forbidden file, unknown errors
+// are restored with their message only.
+func restoreForbiddenError(ff fullForbiddenFile) error {
+	if ff.Violation != nil {
+		violation := *ff.Violation
+		violation.Err = ErrRuleViolation
+		return violation
+	}
+
+	return restoreError(ff.Error)
+}
+
+// restoreError returns the sentinel error (see restoredErrors) with the given message, errors wrapping a sentinel
+// (e.g. "broken symlink: target") wrap it again. Unknown errors are restored with their message only.
+func restoreError(message string) error {
+	for _, sentinel := range restoredErrors {
+		if message == sentinel.Error() {
+			return sentinel
+		}
+		if detail, ok := strings.CutPrefix(message, sentinel.Error()+": "); ok {
+			return fmt.Errorf("%w: %s", sentinel, detail)
+		}
+	}
+
+	if message == "" {
+		return nil
+	}
+
+	return errors.New(message)
+}
@@ -0,0 +1,122 @@
+package release_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfo_MarshalFull(t *testing.T) {
+	fsys := fstest.MapFS{
+		"TVPack.1967.S01.German.1080p.BluRay.x264-Group/s01e01-group.mkv": {Data: []byte("abcdefghijklmnopqrstuvwxyz0123456789")},
+		"TVPack.1967.S01.German.1080p.BluRay.x264-Group/s01e02-group.mkv": {Data: []byte("abcd")},
+		"TVPack.1967.S01.German.1080p.BluRay.x264-Group/group.nfo":        {Data: []byte("imdb.com/title/tt0123456\n")},
+		"TVPack.1967.S01.German.1080p.BluRay.x264-Group/release.nzb":      {Data: []byte("abc")},
+		"TVPack.1967.S01.German.1080p.BluRay.x264-Group/empty.srt":        {Data: []byte{}},
+	}
+
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	parsed, err := releaseService.ParseFS(fsys, "TVPack.1967.S01.German.1080p.BluRay.x264-Group")
+	assert.ErrorIs(t, err, release.ErrForbiddenFiles)
+	require.NotNil(t, parsed)
+
+	parsed.PreInfo = &release.Pre{Name: parsed.Name, Nuke: "bad.ivtc", Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	parsed.MediaInfo = &release.MediaInfo{
+		Media: release.Media{Tracks: []release.MediaInfoTrack{{Type: "Video", Width: "1920", Height: "1080"}}},
+	}
+
+	data, err := parsed.MarshalFull()
+	require.NoError(t, err)
+
+	loaded, err := release.LoadInfo(data)
+	require.NoError(t, err)
+
+	compareRelease(t, *parsed, *loaded)
+
+	assert.Equal(t, parsed.BaseDir, loaded.BaseDir)
+	assert.Equal(t, parsed.PreInfo, loaded.PreInfo)
+	assert.True(t, loaded.HasNuke())
+	assert.Equal(t, parsed.MediaInfo, loaded.MediaInfo)
+	assert.Equal(t, release.FHD, loaded.MediaInfo.GetNearestResolution())
+	assert.True(t, loaded.HasExtensions(".mkv", ".nfo"))
+
+	// the nodes are linked into the tree again
+	require.NotNil(t, loaded.Root)
+	node, err := loaded.Root.GetFile("s01e02-group.mkv")
+	require.NoError(t, err)
+	assert.Same(t, loaded.Root, node.Parent)
+	assert.Same(t, node, loaded.Episodes[1].File)
+
+	biggest, err := loaded.Root.GetFile("s01e01-group.mkv")
+	require.NoError(t, err)
+	assert.Same(t, biggest, loaded.BiggestFile)
+
+	assert.Len(t, loaded.MediaFiles.GetByExtensions(".mkv"), 2)
+	for _, mediaFile := range loaded.MediaFiles {
+		assert.Same(t, loaded.Root, mediaFile.Parent)
+	}
+
+	// the forbidden files keep their sentinel errors
+	require.Len(t, loaded.ForbiddenFiles, len(parsed.ForbiddenFiles))
+	for i, forbidden := range loaded.ForbiddenFiles {
+		assert.Equal(t, parsed.ForbiddenFiles[i].FullPath, forbidden.FullPath)
+		assert.ErrorIs(t, forbidden.Error, parsed.ForbiddenFiles[i].Error)
+	}
+
+	t.Run("check findings", func(t *testing.T) {
+		findings := []struct {
+			err, sentinel error
+		}{
+			{err: fmt.Errorf("%w: size mismatch", release.ErrSrrValidationFailed), sentinel: release.ErrSrrValidationFailed},
+			{err: fmt.Errorf("%w: 2", release.ErrMissingDisc), sentinel: release.ErrMissingDisc},
+			{err: release.ErrCRCValidationFailed, sentinel: release.ErrCRCValidationFailed},
+		}
+
+		rel := *parsed
+		rel.Report = &release.Report{}
+		for _, f := range findings {
+			rel.Report.Findings = append(rel.Report.Findings, release.Finding{
+				Code: release.CodeSrrMismatch, Severity: release.SeverityError, Message: f.err.Error(), Err: f.err,
+			})
+		}
+
+		data, err := rel.MarshalFull()
+		require.NoError(t, err)
+
+		loaded, err := release.LoadInfo(data)
+		require.NoError(t, err)
+
+		require.Len(t, loaded.Report.Findings, len(findings))
+		for i, finding := range loaded.Report.Findings {
+			assert.ErrorIs(t, finding, findings[i].sentinel, finding.Message)
+			assert.Equal(t, findings[i].err.Error(), finding.Err.Error())
+		}
+	})
+
+	// the default encoding is not changed
+	defaultData, err := json.Marshal(parsed)
+	require.NoError(t, err)
+	assert.NotContains(t, string(defaultData), `"root"`)
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := release.LoadInfo([]byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown node", func(t *testing.T) {
+		_, err := release.LoadInfo([]byte(`{"biggest_file": "missing.mkv"}`))
+		assert.ErrorIs(t, err, release.ErrFileNotFound)
+	})
+}
@@ -0,0 +1,210 @@
+package release
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Languages holds the audio and subtitle languages of a release as ISO 639-1 codes, e.g. "de" or "en".
+type Languages struct {
+	// Audio holds the languages of the audio tracks, e.g. German of "Movie.German.DL" or "TRUEFRENCH".
+	Audio []string `json:"audio,omitempty"`
+	// Subs holds the languages of the subtitles, e.g. German of "Movie.German.Subbed" or French of "VOSTFR".
+	Subs []string `json:"subs,omitempty"`
+	// Dubbed is true if the audio is dubbed (DUBBED, VFF, VFQ).
+	Dubbed bool `json:"dubbed,omitempty"`
+	// Multi is true if the release has more than one audio language (DL, ML, MULTi or more than one audio track).
+	Multi bool `json:"multi,omitempty"`
+}
+
+// languageCodes maps the names and codes of a language (ISO 639-1, ISO 639-2/B and /T, English and native names)
+// to its ISO 639-1 code.
+var languageCodes = func() map[string]string {
+	aliases := map[string][]string{
+		"ar": {"ara", "arabic"},
+		"bg": {"bul", "bulgarian"},
+		"cs": {"cze", "ces", "czech"},
+		"da": {"dan", "danish", "dansk"},
+		"de": {"ger", "deu", "german", "deutsch"},
+		"el": {"gre", "ell", "greek"},
+		"en": {"eng", "english"},
+		"es": {"spa", "spanish", "espanol", "castellano", "latino"},
+		"fi": {"fin", "finnish", "suomi"},
+		"fr": {"fre", "fra", "french", "francais", "truefrench"},
+		"he": {"heb", "hebrew"},
+		"hi": {"hin", "hindi"},
+		"hu": {"hun", "hungarian", "magyar"},
+		"it": {"ita", "italian", "italiano"},
+		"ja": {"jpn", "jap", "japanese"},
+		"ko": {"kor", "korean"},
+		"nl": {"dut", "nld", "dutch", "flemish"},
+		"no": {"nor", "nob", "norwegian", "norsk"},
+		"pl": {"pol", "polish"},
+		"pt": {"por", "portuguese"},
+		"ro": {"rum", "ron", "romanian"},
+		"ru": {"rus", "russian"},
+		"sv": {"swe", "swedish", "svenska"},
+		"th": {"tha", "thai"},
+		"tr": {"tur", "turkish"},
+		"uk": {"ukr", "ukrainian"},
+		"zh": {"chi", "zho", "chinese", "mandarin", "cantonese"},
+	}
+
+	codes := make(map[string]string)
+	for code, names := range aliases {
+		codes[code] = code
+		for _, name := range names {
+			codes[name] = code
+		}
+	}
+
+	return codes
+}()
+
+// NormalizeLanguage returns the ISO 639-1 code of a language name or code, e.g. "de" for "German", "ger" or "de-DE".
+// Unknown languages are returned in lowercase.
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+
+	if code, ok := languageCodes[language]; ok {
+		return code
+	}
+
+	// regional codes like de-DE or pt_BR
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		if code, ok := languageCodes[language[:i]]; ok {
+			return code
+		}
+	}
+
+	return language
+}
+
+// languageRegexes holds the patterns of ParseLanguages.
+var languageRegexes = struct {
+	name, code, subbed, dubbed, multi, subtitled, frenchDub *regexp.Regexp
+}{
+	name: tokenPattern(strings.Join(languageNames(func(name string) bool { return len(name) > 3 }), "|")),
+	// ISO 639-2 codes are only matched in upper case (e.g. GER or ENG), two letter codes are not matched at all,
+	// both are too common in titles (e.g. "It" or "Fin")
+	code: regexp.MustCompile(`(?:^|[._ -])(` +
+		strings.ToUpper(strings.Join(languageNames(func(name string) bool { return len(name) == 3 }), "|")) +
+		`)(?:[._ -]|$)`),
+	subbed:    tokenPattern(`subbed|subs?`),
+	dubbed:    tokenPattern(`dubbed`),
+	multi:     tokenPattern(`dl|ml|multi(?:[._-]?(?:lang|audio))?|dual[._-]?audio`),
+	subtitled: tokenPattern(`vostfr|vost`),
+	frenchDub: tokenPattern(`vff|vfq|vfi|vf2`),
+}
+
+// languageNames returns the names and codes of languageCodes accepted by the filter, the longest first.
+func languageNames(filter func(name string) bool) []string {
+	var names []string
+	for name := range languageCodes {
+		if filter(name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+
+	return names
+}
+
+// ParseLanguages parses the audio and subtitle languages and the dubbing tags of a release name.
+// A language directly in front of or after SUBBED is a subtitle language, e.g. "German.Subbed", all other
+// languages are audio languages.
+func ParseLanguages(name string) Languages {
+	var languages Languages
+
+	subbed := findTokens(languageRegexes.subbed, name)
+
+	tokens := append(findTokens(languageRegexes.name, name), findTokens(languageRegexes.code, name)...)
+	slices.SortFunc(tokens, func(a, b Token) int { return a.Start - b.Start })
+
+	for _, token := range tokens {
+		code := NormalizeLanguage(token.Value)
+
+		if slices.ContainsFunc(subbed, func(sub Token) bool { return isAdjacentToken(name, token, sub) }) {
+			languages.Subs = appendLanguage(languages.Subs, code)
+			continue
+		}
+
+		languages.Audio = appendLanguage(languages.Audio, code)
+	}
+
+	if languageRegexes.subtitled.MatchString(name) {
+		languages.Subs = appendLanguage(languages.Subs, "fr")
+	}
+
+	if languageRegexes.frenchDub.MatchString(name) {
+		languages.Audio = appendLanguage(languages.Audio, "fr")
+		languages.Dubbed = true
+	}
+
+	languages.Dubbed = languages.Dubbed || languageRegexes.dubbed.MatchString(name)
+	languages.Multi = len(languages.Audio) > 1 || languageRegexes.multi.MatchString(name)
+
+	return languages
+}
+
+// isAdjacentToken checks if only separators are between the two tokens.
+func isAdjacentToken(name string, a, b Token) bool {
+	if a.Start > b.Start {
+		a, b = b, a
+	}
+
+	between := name[a.End:b.Start]
+	return len(between) > 0 && strings.Trim(between, "._- ") == ""
+}
+
+// appendLanguage adds the language if it's not empty and not in the list yet.
+func appendLanguage(languages []string, language string) []string {
+	if language == "" || slices.Contains(languages, language) {
+		return languages
+	}
+	return append(languages, language)
+}
+
+// Merge returns the languages of both, e.g. the languages of the name and the mediainfo.
+func (l Languages) Merge(other Languages) Languages {
+	merged := Languages{
+		Audio:  slices.Clone(l.Audio),
+		Subs:   slices.Clone(l.Subs),
+		Dubbed: l.Dubbed || other.Dubbed,
+	}
+
+	for _, language := range other.Audio {
+		merged.Audio = appendLanguage(merged.Audio, language)
+	}
+	for _, language := range other.Subs {
+		merged.Subs = appendLanguage(merged.Subs, language)
+	}
+
+	merged.Multi = l.Multi || other.Multi || len(merged.Audio) > 1
+
+	return merged
+}
+
+// HasAnyAudio checks if any of the given languages (names or codes, see NormalizeLanguage) is an audio language.
+func (l Languages) HasAnyAudio(languages ...string) bool {
+	return containsLanguage(l.Audio, languages)
+}
+
+// HasAnySubs checks if any of the given languages (names or codes, see NormalizeLanguage) is a subtitle language.
+func (l Languages) HasAnySubs(languages ...string) bool {
+	return containsLanguage(l.Subs, languages)
+}
+
+// containsLanguage checks if any of the languages is in the list of ISO codes.
+func containsLanguage(codes []string, languages []string) bool {
+	return slices.ContainsFunc(languages, func(language string) bool {
+		return slices.Contains(codes, NormalizeLanguage(language))
+	})
+}
@@ -0,0 +1,87 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected release.Languages
+	}{
+		{"No Language", "Movie.Title.2023.1080p.BluRay.x264-GROUP", release.Languages{}},
+		{"German", "Movie.Title.2023.German.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"de"}}},
+		{"German DL", "Movie.Title.2023.German.DL.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"de"}, Multi: true}},
+		{"German Subbed", "Movie.Title.2023.German.Subbed.1080p.BluRay.x264-GROUP", release.Languages{Subs: []string{"de"}}},
+		{"Subbed German", "Movie.Title.2023.subbed.german.1080p.BluRay.x264-GROUP", release.Languages{Subs: []string{"de"}}},
+		{
+			"Multi", "Movie.Title.2023.MULTi.French.German.1080p.BluRay.x264-GROUP",
+			release.Languages{Audio: []string{"fr", "de"}, Multi: true},
+		},
+		{"ML", "Movie.Title.2023.GERMAN.ML.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"de"}, Multi: true}},
+		{"Dubbed", "Movie.Title.2023.German.DUBBED.1080p.WEB.h264-GROUP", release.Languages{Audio: []string{"de"}, Dubbed: true}},
+		{"VOSTFR", "Show.S01E01.VOSTFR.1080p.WEB.h264-GROUP", release.Languages{Subs: []string{"fr"}}},
+		{"TRUEFRENCH", "Movie.Title.2023.TRUEFRENCH.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"fr"}}},
+		{"VFF", "Movie.Title.2023.MULTi.VFF.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"fr"}, Dubbed: true, Multi: true}},
+		{"ISO Codes", "Movie.Title.2023.GER.ENG.1080p.BluRay.x264-GROUP", release.Languages{Audio: []string{"de", "en"}, Multi: true}},
+		{"Lowercase Code in Title", "La.Fin.Du.Monde.2023.1080p.BluRay.x264-GROUP", release.Languages{}},
+		{"Two Letter Code in Title", "It.Follows.2014.1080p.BluRay.x264-GROUP", release.Languages{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, release.ParseLanguages(tt.filename), "Filename: %s", tt.filename)
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"German":  "de",
+		"deutsch": "de",
+		"GER":     "de",
+		"deu":     "de",
+		"de":      "de",
+		"de-DE":   "de",
+		"pt_BR":   "pt",
+		"fre":     "fr",
+		"Klingon": "klingon",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, release.NormalizeLanguage(input), "Language: %s", input)
+	}
+}
+
+func TestLanguages_Merge(t *testing.T) {
+	name := release.Languages{Audio: []string{"de"}, Subs: []string{"de"}, Dubbed: true}
+	mediaInfo := release.Languages{Audio: []string{"de", "en"}, Subs: []string{"en"}}
+
+	merged := name.Merge(mediaInfo)
+	assert.Equal(t, release.Languages{Audio: []string{"de", "en"}, Subs: []string{"de", "en"}, Dubbed: true, Multi: true}, merged)
+
+	// merging does not change the receiver
+	assert.Equal(t, []string{"de"}, name.Audio)
+}
+
+func TestMediaInfo_Languages(t *testing.T) {
+	mediaInfo := &release.MediaInfo{
+		Media: release.Media{
+			Tracks: []release.MediaInfoTrack{
+				{Type: string(release.General)},
+				{Type: string(release.Audio), Language: "de"},
+				{Type: string(release.Audio), Language: "en-US"},
+				{Type: string(release.Text), Language: "German"},
+				{Type: string(release.Text)},
+			},
+		},
+	}
+
+	assert.Equal(t, release.Languages{Audio: []string{"de", "en"}, Subs: []string{"de"}, Multi: true}, mediaInfo.Languages())
+	assert.True(t, mediaInfo.HasAnyLanguage("english"))
+	assert.False(t, mediaInfo.HasAnyLanguage("french"))
+}
@@ -86,6 +86,9 @@ type MediaInfoTrack struct {
 	ChromaSubsampling              string              `json:"ChromaSubsampling,omitempty"`
 	BitDepth                       string              `json:"BitDepth,omitempty"`
 	ScanType                       string              `json:"ScanType,omitempty"`
+	HDRFormat                      string              `json:"HDR_Format,omitempty"`
+	HDRFormatCompatibility         string              `json:"HDR_Format_Compatibility,omitempty"`
+	MultiViewCount                 string              `json:"MultiView_Count,omitempty"`
 	Delay                          string              `json:"Delay,omitempty"`
 	Default                        string              `json:"Default,omitempty"`
 	Forced                         string              `json:"Forced,omitempty"`
@@ -190,25 +193,32 @@ func (m *MediaInfo) GetAttachmentNames(extensions ...string) []string {
 	return names
 }
 
-// HasAnyLanguage checks if any audio tracks in the collection match the specified languages (case-insensitive).
+// HasAnyLanguage checks if any audio tracks in the collection match the specified languages.
+// Names and codes are normalized before the comparison (see NormalizeLanguage), e.g. "german" matches "de".
 func (m *MediaInfo) HasAnyLanguage(languages ...string) bool {
-	if len(m.Media.Tracks) == 0 {
-		return false
-	}
+	return m.Languages().HasAnyAudio(languages...)
+}
+
+// Languages returns the normalized languages of the audio and text tracks.
+func (m *MediaInfo) Languages() Languages {
+	var languages Languages
 
 	for _, track := range m.Media.Tracks {
-		if track.Type != string(Audio) {
+		if track.Language == "" {
 			continue
 		}
 
-		exists := slices.ContainsFunc(languages, func(s string) bool {
-			return strings.EqualFold(s, track.Language)
-		})
-		if exists {
-			return true
+		switch track.Type {
+		case string(Audio):
+			languages.Audio = appendLanguage(languages.Audio, NormalizeLanguage(track.Language))
+		case string(Text):
+			languages.Subs = appendLanguage(languages.Subs, NormalizeLanguage(track.Language))
 		}
 	}
-	return false
+
+	languages.Multi = len(languages.Audio) > 1
+
+	return languages
 }
 
 // GetImdbID checks for an existing imdb id in the extra track fields.
@@ -243,23 +253,24 @@ func (m *MediaInfo) GetNearestResolution() Resolution {
 	}
 
 	standardResolutions := []resolutionDimension{
-		{SD, 640, 480},  // VGA
-		{SD, 854, 480},  // FWVGA
-		{SD, 720, 576},  // Standard Definition (PAL)
-		{SD, 720, 480},  // Standard Definition (NTSC)
-		{SD, 960, 540},  // qHD
-		{HD, 960, 720},  // HD720
-		{HD, 1280, 720}, // HD (720p)
-		{HD, 1280, 800}, // WXGA
-		{HD, 1366, 768}, // WXGA (Widescreen Extended Graphics Array)
-		{HD, 1152, 720}, // Proportional widescreen 720p
-		{HD, 1280, 768}, // WXGA (16:9 aspect ratio)
-		{HD, 1280, 800}, // WXGA (16:10 aspect ratio)
+		{SD480, 640, 480}, // VGA
+		{SD480, 854, 480}, // FWVGA
+		{SD576, 720, 576}, // Standard Definition (PAL)
+		{SD480, 720, 480}, // Standard Definition (NTSC)
+		{SD, 960, 540},    // qHD
+		{HD, 960, 720},    // HD720
+		{HD, 1280, 720},   // HD (720p)
+		{HD, 1280, 800},   // WXGA
+		{HD, 1366, 768},   // WXGA (Widescreen Extended Graphics Array)
+		{HD, 1152, 720},   // Proportional widescreen 720p
+		{HD, 1280, 768},   // WXGA (16:9 aspect ratio)
+		{HD, 1280, 800},   // WXGA (16:10 aspect ratio)
 		{FHD, 1440, 900},
-		{FHD, 1440, 1080}, // Anamorphic Full HD
-		{FHD, 1600, 900},  // HD+
-		{FHD, 1920, 1080}, // Full HD (1080p)
-		{UHD, 3840, 2160}, // Ultra HD (4K)
+		{FHD, 1440, 1080},   // Anamorphic Full HD
+		{FHD, 1600, 900},    // HD+
+		{FHD, 1920, 1080},   // Full HD (1080p)
+		{UHD, 3840, 2160},   // Ultra HD (4K)
+		{UHD8K, 7680, 4320}, // Ultra HD (8K)
 	}
 
 	var (
@@ -285,8 +296,11 @@ func (m *MediaInfo) GetNearestResolution() Resolution {
 		return ""
 	}
 
-	// UHD is the highest resolution, so check for that first
-	if height >= 2160 {
+	// heights from 4K on are UHD8K or UHD, so the highest resolution is checked first
+	switch {
+	case height >= 4320:
+		return UHD8K
+	case height >= 2160:
 		return UHD
 	}
 
@@ -90,7 +90,7 @@ func TestGetNearestResolution(t *testing.T) {
 			expected: SD,
 		},
 		{
-			desc: "resolution below SD",
+			desc: "vga resolution",
 			mediaInfo: &MediaInfo{
 				Media: Media{
 					Tracks: []MediaInfoTrack{
@@ -98,7 +98,29 @@ func TestGetNearestResolution(t *testing.T) {
 					},
 				},
 			},
-			expected: SD,
+			expected: SD480,
+		},
+		{
+			desc: "pal resolution",
+			mediaInfo: &MediaInfo{
+				Media: Media{
+					Tracks: []MediaInfoTrack{
+						{Type: "Video", Width: "720", Height: "576"},
+					},
+				},
+			},
+			expected: SD576,
+		},
+		{
+			desc: "8k resolution",
+			mediaInfo: &MediaInfo{
+				Media: Media{
+					Tracks: []MediaInfoTrack{
+						{Type: "Video", Width: "7680", Height: "4320"},
+					},
+				},
+			},
+			expected: UHD8K,
 		},
 		{
 			desc: "multiple tracks with valid video",
@@ -2,20 +2,25 @@ package release
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 )
 
 // Resolution represents the video resolution quality
 type Resolution string
 
 const (
-	SD  Resolution = "sd"
-	HD  Resolution = "720p"
-	FHD Resolution = "1080p"
-	UHD Resolution = "2160p"
+	SD    Resolution = "sd"
+	SD480 Resolution = "480p"
+	SD576 Resolution = "576p"
+	HD    Resolution = "720p"
+	FHD   Resolution = "1080p"
+	UHD   Resolution = "2160p"
+	UHD8K Resolution = "4320p"
 )
 
 // Section represents the category of a release
@@ -53,6 +58,7 @@ const (
 	TV     Section = "tv"
 	TVPack Section = "tv-pack"
 	Sport  Section = "sport"
+	Anime  Section = "anime"
 )
 
 // Adult content categories
@@ -78,113 +84,57 @@ const (
 //go:embed sport_patterns.txt
 var sportSections []byte
 
+// ErrInvalidSportPattern is the error returned by Build when a sport pattern can't be compiled.
+var ErrInvalidSportPattern = errors.New("invalid sport pattern")
+
 // languages is a slice with all the languages to check for in the release name
 var languages = []string{"danish", "dutch", "finnish", "french", "german", "norwegian", "spanish", "swedish", "hebrew"}
 
-// sectionRegexes holds patterns for identifying different section types
-var sectionRegexes = struct {
-	musicSource, videoSource, videoCodec, oldVideo, xxxImageset, tv, ebook, game, gameSection, mobile, tutorial, macOS, linux *regexp.Regexp
-}{
-	musicSource: regexp.MustCompile(`(?i)[_-](web|sat|dvb[sct]|dtv|cable|\d*dvd[sa]?|dat|md|homemade|bootleg|\d*cd[mrs]?|cdep|dvd(rip)?|mbluray|vinyl|lp|vls|tape|sacd|dab|fm|radio)-.*(\d{4}|\d{2}-\d{2}-\d{2})`),
-	videoSource: regexp.MustCompile(`(?i)\.(atv|dtv|hdtv|dvd[59]|bd|uhdbd|bluray|hddvd|web|vhs|hd2dvd)(rip)?[.-]`),
-	videoCodec:  regexp.MustCompile(`(?i)[._-]([xh]26[45]|avc|hevc|vp9|divx|xvid|mpeg2|mp4|vc1|wmv)[._-]`),
-	oldVideo:    regexp.MustCompile(`(?i)[._-](hevc|avc|xvid|divx|vc1|[xh][._]?26[45]|m?dvd[59]?r?|mp4|mpeg2|m?bluray|hd2?dvd|720[ip]|1080[ip]|2160[ip]|xxx)([._-]|$)`),
-	xxxImageset: regexp.MustCompile(`(?i)xxx[._]imageset`),
-	tv:          regexp.MustCompile(`(?i)[._]s\d{2}[de]\d{2,}|s\d{2}|\d{4}[._-]\d{2}[._-]\d{2}|[._]\dx\d{2}[._]|[._]s\d{4}e\d{2}[._]|[._]e\d{2,}[._]|[._]d\d{2}[._]`),
-	ebook:       regexp.MustCompile(`(?i)[._-](ebook|epub|pdf|cbr|cbz)([._-]|$)`),
-	game:        regexp.MustCompile(`(?i)[._-](ps[1-5]|xbox(one|360)?|nsw|wiiu?|linux)[._-]`),
-	gameSection: regexp.MustCompile(`(?i)^(games|ps\d|playstation|wii|xbox|x360|nsw|nintendo|nds)`),
-	mobile:      regexp.MustCompile(`(?i)[._-]android[._-]|apk$`),
-	tutorial:    regexp.MustCompile(`(?i)([._-]tutorials?|lectures?[._-])|^udemy[._-]`),
-	macOS:       regexp.MustCompile(`(?i)[._-]macosx?[._-]`),
-	linux:       regexp.MustCompile(`(?i)[._-]linux[._-]`),
-}
-
-// videoRegexes holds patterns for identifying video content types
-var videoRegexes = struct {
-	xxx, imageSet, clips, dvd, pack, tvPack, mvid, noSport *regexp.Regexp
-}{
-	xxx:      regexp.MustCompile(`(?i)[._]xxx[._]?`),
-	imageSet: regexp.MustCompile(`(?i)[._]imagesets?[._-]?`),
-	clips:    regexp.MustCompile(`(?i)(\d{2}[._]){3}|[._]\d{4}[._]`),
-	dvd:      regexp.MustCompile(`(?i)[._]dvd[59r]?([._-]|$)`),
-	pack:     regexp.MustCompile(`(?i)[._]pack[._-]`),
-	tvPack:   regexp.MustCompile(`(?i)[._](s\d{2})[._]`),
-	mvid:     regexp.MustCompile(`(?i)-\d{4}-|[._-](mbluray|[ck]on[cz]ert)[._-]`),
-	noSport:  regexp.MustCompile(`(?i)[._-](do[ck]u(mentation)?|(s(taffel)?\d+)?e(pisode)?\d+)[._-]`),
-}
-
-// audioRegexes holds patterns for identifying audio content types
-var audioRegexes = struct {
-	flac, aBook *regexp.Regexp
-}{
-	flac:  regexp.MustCompile(`(?i)[_-]flac[_-]`),
-	aBook: regexp.MustCompile(`(?i)[_-](abook|audiobook|hoerbuch)`),
-}
-
-// gameRegexes holds patterns for identifying game platforms
-var gameRegexes = struct {
-	wii, playStation *regexp.Regexp
-}{
-	wii:         regexp.MustCompile(`(?i)wiiu?|nsw`),
-	playStation: regexp.MustCompile(`(?i)[._-](ps|playstation)[1-5][._-]`),
-}
-
 // resRegexes holds patterns for identifying video resolutions
 var resRegexes = struct {
-	fhd, ultraHD *regexp.Regexp
+	fhd, ultraHD, ultraHD8K *regexp.Regexp
 }{
-	fhd:     regexp.MustCompile(`(?i)complete[._-]m?bluray|[._-]fhd(2[45]p)?[._-]`),
-	ultraHD: regexp.MustCompile(`(?i)([._-]uhd[._-]m?bluray)|[._]4k[._]bluray`),
+	fhd:       regexp.MustCompile(`(?i)complete[._-]m?bluray|[._-]fhd(2[45]p)?[._-]`),
+	ultraHD:   tokenPattern(`uhd|4k`),
+	ultraHD8K: tokenPattern(`8k`),
 }
 
-// ParseSection tries to determine the section for the given release name
+// ParseSection tries to determine the section for the given release name.
+// The classifiers added with WithSectionClassifier are asked first, then the builtin detection
+// (see DefaultSectionClassifier) and the classifiers added with WithFallbackSectionClassifier.
 func (s *Service) ParseSection(name string, preInfo *Pre) Section {
-	name = strings.ToLower(name)
-	preSection := ""
-
-	if preInfo != nil {
-		preSection = strings.ToLower(preInfo.Section)
-	}
-
-	// Try primary section detection
-	section := s.detectPrimarySection(name, preSection)
-
-	// Try fallback detection if primary detection failed
-	if section == Unknown {
-		section = s.detectFallbackSection(name)
-	}
-
-	return section
+	return s.classifySection(name, preInfo, nil)
 }
 
 // detectPrimarySection attempts to identify the section based on common patterns
-func (s *Service) detectPrimarySection(name string, preSection string) Section {
+func (s *Service) detectPrimarySection(name string, preSection string, d *sectionDetection) Section {
 	switch {
-	case sectionRegexes.xxxImageset.MatchString(name):
-		return XXXImagesets
-	case sectionRegexes.musicSource.MatchString(name):
-		return parseAudio(name)
-	case sectionRegexes.oldVideo.MatchString(name):
-		return s.parseVideo(name, preSection)
-	case sectionRegexes.ebook.MatchString(name):
-		return Ebooks
+	case d.match("anime", name):
+		return d.decide(Anime, confidencePattern)
+	case d.match("xxxImageset", name):
+		return d.decide(XXXImagesets, confidencePattern)
+	case d.match("musicSource", name):
+		return parseAudio(name, d)
+	case d.match("oldVideo", name):
+		return s.parseVideo(name, preSection, d)
+	case d.match("ebook", name):
+		return d.decide(Ebooks, confidencePattern)
 	case preSection != "":
-		return s.detectSectionFromPreSection(name, preSection)
+		return s.detectSectionFromPreSection(name, preSection, d)
 	}
 
 	return Unknown
 }
 
 // detectSectionFromPreSection uses pre-release information to help identify the section
-func (s *Service) detectSectionFromPreSection(name string, preSection string) Section {
+func (s *Service) detectSectionFromPreSection(name string, preSection string, d *sectionDetection) Section {
 	switch {
-	case sectionRegexes.gameSection.MatchString(preSection):
-		return parseGame(name, true)
-	case slices.Contains([]string{"0day", "apps"}, preSection):
-		return parseApp(name)
-	case slices.Contains([]string{"abooks", "abook", "mp3", "flac"}, preSection):
-		return parseAudio(name)
+	case d.match("gameSection", preSection):
+		return parseGame(name, true, d)
+	case d.match("appsPreSection", preSection):
+		return parseApp(name, d)
+	case d.match("audioPreSection", preSection):
+		return parseAudio(name, d)
 	}
 	// slices.ContainsFunc([]string{"abook", "mp3", "flac"}, func(s string) bool {
 	// 	return strings.Contains(s, strings.ToLower(pre.Section))
@@ -194,156 +144,171 @@ func (s *Service) detectSectionFromPreSection(name string, preSection string) Se
 }
 
 // detectFallbackSection tries alternative detection methods
-func (s *Service) detectFallbackSection(name string) Section {
+func (s *Service) detectFallbackSection(name string, d *sectionDetection) Section {
 	switch {
-	case sectionRegexes.tutorial.MatchString(name):
-		return Tutorials
-	case sectionRegexes.mobile.MatchString(name):
-		return Mobile
-	case sectionRegexes.game.MatchString(name):
-		return parseGame(name, false)
+	case d.match("tutorial", name):
+		return d.decide(Tutorials, confidenceFallback)
+	case d.match("mobile", name):
+		return d.decide(Mobile, confidenceFallback)
+	case d.match("game", name):
+		return parseGame(name, false, d)
 	}
 
 	return Unknown
 }
 
 // parseXXXContent identifies the specific type of adult content
-func parseXXXContent(name string) Section {
+func parseXXXContent(name string, d *sectionDetection) Section {
 	switch {
-	case videoRegexes.imageSet.MatchString(name):
-		return XXXImagesets
-	case videoRegexes.clips.MatchString(name):
-		return XXXClips
-	case videoRegexes.dvd.MatchString(name):
-		return XXXDVD
-	case videoRegexes.pack.MatchString(name):
-		return XXXPack
+	case d.match("imageSet", name):
+		return d.decide(XXXImagesets, confidencePattern)
+	case d.match("clips", name):
+		return d.decide(XXXClips, confidencePattern)
+	case d.match("dvd", name):
+		return d.decide(XXXDVD, confidencePattern)
+	case d.match("pack", name):
+		return d.decide(XXXPack, confidencePattern)
 	default:
-		return XXXMovies
+		return d.fallback(XXXMovies)
 	}
 }
 
 // parseVideo identifies the specific type of video content
-func (s *Service) parseVideo(name string, preSection string) Section {
+func (s *Service) parseVideo(name string, preSection string, d *sectionDetection) Section {
 	// Check for adult content first
-	if videoRegexes.xxx.MatchString(name) {
-		return parseXXXContent(name)
+	if d.match("xxx", name) {
+		return parseXXXContent(name, d)
 	}
 
 	// Check for sports content
-	if !videoRegexes.noSport.MatchString(name) && s.isSport(name) {
-		return Sport
+	if !d.match("noSport", name) && d.check("sport", s.isSport(name)) {
+		return d.decide(Sport, confidencePattern)
 	}
 
 	// Check for music video content
-	if slices.Contains([]string{"music", "mbluray", "mvid"}, preSection) ||
-		videoRegexes.mvid.MatchString(name) {
-		return AudioVideo
+	if d.match("mvidPreSection", preSection) {
+		return d.decide(AudioVideo, confidencePre)
+	}
+	if d.match("mvid", name) {
+		return d.decide(AudioVideo, confidencePattern)
 	}
 
 	// Check for TV pack content
-	if videoRegexes.tvPack.MatchString(name) {
-		return TVPack
+	if d.match("tvPack", name) {
+		return d.decide(TVPack, confidencePattern)
 	}
 
 	// Check for TV content
-	if sectionRegexes.tv.MatchString(name) {
-		return TV
+	if d.match("tv", name) {
+		return d.decide(TV, confidencePattern)
 	}
 
 	// Default to Movies
-	return Movies
+	return d.fallback(Movies)
 }
 
 // parseAudio identifies the specific type of audio content
-func parseAudio(name string) Section {
+func parseAudio(name string, d *sectionDetection) Section {
 	switch {
-	case audioRegexes.aBook.MatchString(name):
-		return AudioBooks
-	case audioRegexes.flac.MatchString(name):
-		return AudioFLAC
-	case sectionRegexes.videoCodec.MatchString(name):
-		return AudioVideo
+	case d.match("aBook", name):
+		return d.decide(AudioBooks, confidencePattern)
+	case d.match("flac", name):
+		return d.decide(AudioFLAC, confidencePattern)
+	case d.match("videoCodec", name):
+		return d.decide(AudioVideo, confidencePattern)
 	default:
-		return AudioMP3
+		return d.fallback(AudioMP3)
 	}
 }
 
 // parseGame identifies the specific gaming platform
-func parseGame(name string, hasPreSection bool) Section {
+func parseGame(name string, hasPreSection bool, d *sectionDetection) Section {
 	switch {
-	case strings.Contains(name, "xbox"):
-		return GamesXbox
-	case gameRegexes.wii.MatchString(name):
-		return GamesNintendo
-	case gameRegexes.playStation.MatchString(name):
-		return GamesPlaystation
-	case sectionRegexes.linux.MatchString(name):
-		return GamesLinux
-	case sectionRegexes.macOS.MatchString(name):
-		return GamesMacOS
+	case d.match("xbox", name):
+		return d.decide(GamesXbox, confidencePattern)
+	case d.match("wii", name):
+		return d.decide(GamesNintendo, confidencePattern)
+	case d.match("playStation", name):
+		return d.decide(GamesPlaystation, confidencePattern)
+	case d.match("linux", name):
+		return d.decide(GamesLinux, confidencePattern)
+	case d.match("macOS", name):
+		return d.decide(GamesMacOS, confidencePattern)
 	case hasPreSection:
-		return GamesWindows
+		return d.fallback(GamesWindows)
 	}
 
 	return Unknown
 }
 
 // parseApp identifies the specific type of application
-func parseApp(name string) Section {
+func parseApp(name string, d *sectionDetection) Section {
 	switch {
-	case strings.Contains(name, "crossplatform"):
-		return AppsMisc
-	case sectionRegexes.macOS.MatchString(name):
-		return AppsMacOS
-	case sectionRegexes.linux.MatchString(name):
-		return AppsLinux
+	case d.match("crossplatform", name):
+		return d.decide(AppsMisc, confidencePattern)
+	case d.match("macOS", name):
+		return d.decide(AppsMacOS, confidencePattern)
+	case d.match("linux", name):
+		return d.decide(AppsLinux, confidencePattern)
 	default:
-		return AppsWindows
+		return d.fallback(AppsWindows)
 	}
 }
 
-// isSport checks if the name contains any sport pattern
+// isSport checks if the name starts with any sport pattern
 func (s *Service) isSport(name string) bool {
-	patterns := s.sportPatterns
+	matcher := s.sportMatcher
+	if matcher == nil {
+		matcher = defaultSportMatcher()
+	}
+
+	return matcher.MatchString(name)
+}
 
-	if len(sportSections) > 0 {
-		patterns = append(patterns, strings.Split(string(sportSections), "\n")...)
+// defaultSportMatcher compiles the embedded sport patterns once, they are checked by the tests.
+var defaultSportMatcher = sync.OnceValue(func() *regexp.Regexp {
+	matcher, err := compileSportMatcher(nil)
+	if err != nil {
+		panic(fmt.Sprintf("compile embedded sport patterns: %v", err))
 	}
+	return matcher
+})
 
-	for _, p := range patterns {
+// compileSportMatcher combines the patterns and the embedded sport patterns into a single regex, which matches
+// a name that starts with any of the patterns followed by a separator.
+func compileSportMatcher(patterns []string) (*regexp.Regexp, error) {
+	var alternatives []string
+
+	for _, p := range slices.Concat(patterns, strings.Split(string(sportSections), "\n")) {
 		p = strings.TrimSpace(p)
 		if p == "" {
 			continue
 		}
 
-		pattern := regexp.MustCompile(fmt.Sprintf("(?i)^%s[._-]", p))
-		if pattern.MatchString(name) {
-			return true
+		// every pattern is compiled on its own to find the invalid one
+		if _, err := regexp.Compile(p); err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSportPattern, p, err)
 		}
+
+		alternatives = append(alternatives, "(?:"+p+")")
 	}
 
-	return false
+	return regexp.Compile(fmt.Sprintf("(?i)^(?:%s)[._-]", strings.Join(alternatives, "|")))
 }
 
-// ParseResolution determines the video resolution from the release name
+// ParseResolution determines the video resolution from the release name.
+// Only whole tokens like "1080p" or "2160i" are matched, the last one wins, because the tags follow the title.
 func ParseResolution(name string) Resolution {
-	name = strings.ToLower(name)
-
-	// Direct resolution matching
-	for _, res := range []Resolution{UHD, FHD, HD} {
-		if strings.Contains(name, string(res)) {
-			return res
-		} else if strings.Contains(name, string(res[:len(res)-1]+"i")) {
-			// Check for 720i, 1080i, 2160i variants
-			return res
-		}
+	if tokens := findTokens(nameRegexes.resolution, name); len(tokens) > 0 {
+		return resolutionOfToken(tokens[len(tokens)-1])
 	}
 
 	// Pattern-based resolution detection
 	switch {
 	case resRegexes.fhd.MatchString(name):
 		return FHD
+	case resRegexes.ultraHD8K.MatchString(name):
+		return UHD8K
 	case resRegexes.ultraHD.MatchString(name):
 		return UHD
 	default:
@@ -351,7 +316,13 @@ func ParseResolution(name string) Resolution {
 	}
 }
 
-// ParseLanguage identifies the language from the release name
+// resolutionOfToken returns the resolution of a resolution token, the interlaced variants like 1080i are
+// mapped to the progressive resolution.
+func resolutionOfToken(token Token) Resolution {
+	return Resolution(token.Value[:len(token.Value)-1] + "p")
+}
+
+// ParseLanguage identifies the language from the release name, see ParseLanguages for audio and subtitle languages.
 func ParseLanguage(name string) string {
 	name = strings.ToLower(name)
 
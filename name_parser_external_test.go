@@ -5,10 +5,12 @@ import (
 
 	"github.com/f4n4t/go-release"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseSection(t *testing.T) {
-	releaseService := release.NewServiceBuilder().WithSkipPre(true).Build()
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
 
 	tests := []struct {
 		name        string
@@ -46,6 +48,7 @@ func TestParseSection(t *testing.T) {
 		// TV Shows - Season packs and alternative formats
 		{"TV - Anime Season", "To.Your.Eternity.2021.S01.ANiME.German.AAC.1080p.WEBRiP.HEVC-DS7", "", release.TVPack},
 		{"TV - Season Pack", "Game.of.Thrones.S08.1080p.BluRay.x264-ROVERS", "", release.TVPack},
+		{"TV - Multi-Season Pack", "Game.of.Thrones.S01-S03.1080p.BluRay.x264-ROVERS", "", release.TVPack},
 		{"TV - Season with Episodes", "Friends.S01E01-E24.1080p.BluRay.x264-GROUP", "", release.TV},
 		{"TV - Alternative Episode Format", "Succession.1x09.1080p.WEB.H264-GLHF", "", release.TV},
 		{"TV - Alternative Season Format", "The.Office.S05.D01.German.DL.1080p.BluRay.x264-RSG", "", release.TVPack},
@@ -124,6 +127,10 @@ func TestParseSection(t *testing.T) {
 		{"Sport - MMA", "UFC.293.Adesanya.vs.Strickland.Main.Card.1080p.WEB.h264-VERUM", "", release.Sport},
 		{"Sport - Boxing", "Boxing.2023.05.20.Taylor.vs.Cameron.1080p.HDTV.x264-VERUM", "", release.Sport},
 
+		// Anime
+		{"Anime - Episode", "[SubsPlease] Sousou no Frieren - 12 (1080p) [ABCD1234]", "", release.Anime},
+		{"Anime - Batch", "[Group] Title (01-24) [BD 1080p HEVC]", "", release.Anime},
+
 		// E-Books
 		{"Ebook - Magazine", "Tichys.Einblick.No.08.2022.GERMAN.HYBRID.MAGAZINE.eBook-LORENZ", "", release.Ebooks},
 		{"Ebook - Book Series", "Jeny.Han.Sommer.Band.1-3.2011-2012.German.Retail.EPUB.eBook", "", release.Ebooks},
@@ -176,6 +183,38 @@ func TestParseSection(t *testing.T) {
 	}
 }
 
+func TestServiceBuilder_WithSportPatterns(t *testing.T) {
+	patterns := []string{"Curling", "Darts.(WM|PDC)"}
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSportPatterns(patterns).Build()
+	require.NoError(t, err)
+
+	// changing the slice does not change the patterns of the service
+	patterns[0] = "Schach"
+
+	tests := []struct {
+		releaseName string
+		expected    release.Section
+	}{
+		{"Curling.2024.02.10.German.1080p.WEB.h264-GRP", release.Sport},
+		{"Darts.PDC.2024.01.03.German.1080p.WEB.h264-GRP", release.Sport},
+		{"Football.Focus.2022.08.13.1080p.WEB.h264-GRP", release.Sport},
+		{"Schach.2024.02.10.German.1080p.WEB.h264-GRP", release.TV},
+		{"The.Curling.Movie.2024.German.1080p.WEB.h264-GRP", release.Movies},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.releaseName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, releaseService.ParseSection(tt.releaseName, nil))
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := release.NewServiceBuilder().WithSportPatterns([]string{"Darts.(WM"}).Build()
+		assert.ErrorIs(t, err, release.ErrInvalidSportPattern)
+	})
+}
+
 func TestParseResolution(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -203,6 +242,15 @@ func TestParseResolution(t *testing.T) {
 
 		{"Abbreviated UHD", "Movie.Title.2023.4K.BluRay.x265-GROUP", release.UHD},
 		{"HDR indicator", "Movie.Title.2023.HDR.2160p.WEB.x265-GROUP", release.UHD},
+
+		{"NTSC 480p", "Movie.Title.2023.480p.DVDRip.x264-GROUP", release.SD480},
+		{"PAL 576i", "Movie.Title.2023.576i.HDTV.x264-GROUP", release.SD576},
+		{"8K 4320p", "Movie.Title.2023.4320p.WEB.h265-GROUP", release.UHD8K},
+		{"Abbreviated 8K", "Movie.Title.2023.8K.WEB.h265-GROUP", release.UHD8K},
+		{"UHD without number", "Movie.Title.2023.UHD.WEB.h265-GROUP", release.UHD},
+		{"Resolution in title", "Route.1080p.Lost.2023.720p.WEB.h264-GROUP", release.HD},
+		{"Number in title", "Movie.1080.2023.DVDRip.x264-GROUP", release.SD},
+		{"Resolution in brackets", "[Group] Title - 01 [1080p].mkv", release.FHD},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,189 @@
+package release
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ParseManyOptions configures a batch run of ParseMany.
+type ParseManyOptions struct {
+	// Workers is the number of releases parsed at the same time, defaults to the number of CPUs.
+	Workers int
+	// MediaInfoWorkers is the number of mediainfo runs at the same time across all workers, defaults to 1.
+	MediaInfoWorkers int
+	// PreInterval is the minimum time between two pre lookups across all workers, 0 disables the rate limit.
+	PreInterval time.Duration
+	// Ignore holds the ignore patterns passed to every parse.
+	Ignore []string
+}
+
+// ParseResult is the result of a single parse of ParseMany.
+type ParseResult struct {
+	// Root is the path that was parsed.
+	Root string
+	// Info is the parsed release, it can be set together with Err (e.g. ErrForbiddenFiles).
+	Info *Info
+	// Err is the error returned by Parse.
+	Err error
+	// Duration is the time the parse took.
+	Duration time.Duration
+}
+
+// ParseMany parses all roots on a bounded worker pool and streams the results in the order they finish.
+// Pre lookups are shared between the workers, so every release name is only searched once, and can be
+// rate-limited with PreInterval. Mediainfo runs are limited separately from the directory walks.
+// After ctx is canceled no further parses are started, the channel is closed once all workers are done.
+func (s *Service) ParseMany(ctx context.Context, roots []string, opts ParseManyOptions) <-chan ParseResult {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	mediaInfoWorkers := max(1, opts.MediaInfoWorkers)
+
+	worker := *s
+	worker.ctx = ctx
+	// the lookups run on the worker, so canceling ctx also stops them
+	worker.preCache = newPreCache(worker.GetPre, opts.PreInterval)
+	worker.mediaInfoLimit = make(chan struct{}, mediaInfoWorkers)
+
+	rootChan := make(chan string)
+	resultChan := make(chan ParseResult)
+
+	go func() {
+		defer close(rootChan)
+		for _, root := range roots {
+			select {
+			case rootChan <- root:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for range min(workers, max(1, len(roots))) {
+		wg.Go(func() {
+			for root := range rootChan {
+				start := time.Now()
+				info, err := worker.Parse(root, opts.Ignore...)
+
+				select {
+				case resultChan <- ParseResult{Root: root, Info: info, Err: err, Duration: time.Since(start)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		worker.preCache.stop()
+		close(resultChan)
+	}()
+
+	return resultChan
+}
+
+// searchPre searches for pre information, lookups are shared between the workers of ParseMany.
+func (s *Service) searchPre(name string) *Pre {
+	if s.preCache == nil {
+		return s.GetPre(name)
+	}
+	return s.preCache.get(s.ctx, name)
+}
+
+// acquireMediaInfo waits for a free mediainfo slot of ParseMany, the returned function releases it.
+// It returns false if the context was canceled while waiting.
+func (s *Service) acquireMediaInfo() (func(), bool) {
+	if s.mediaInfoLimit == nil {
+		return func() {}, true
+	}
+
+	select {
+	case s.mediaInfoLimit <- struct{}{}:
+		return func() { <-s.mediaInfoLimit }, true
+	case <-s.ctx.Done():
+		return nil, false
+	}
+}
+
+// preCache de-duplicates and rate-limits pre lookups.
+type preCache struct {
+	lookup  func(name string) *Pre
+	limiter *time.Ticker
+
+	mu      sync.Mutex
+	entries map[string]*preCall
+}
+
+// preCall is a single pre lookup, done is closed as soon as pre is set.
+type preCall struct {
+	done chan struct{}
+	pre  *Pre
+}
+
+// newPreCache creates a cache for the given lookup function, an interval <= 0 disables the rate limit.
+func newPreCache(lookup func(name string) *Pre, interval time.Duration) *preCache {
+	pc := &preCache{
+		lookup:  lookup,
+		entries: make(map[string]*preCall),
+	}
+
+	if interval > 0 {
+		pc.limiter = time.NewTicker(interval)
+	}
+
+	return pc
+}
+
+// get returns the pre information for the name, concurrent calls for the same name share one lookup.
+// Only completed lookups are cached, a lookup canceled by ctx is repeated by the next call.
+func (pc *preCache) get(ctx context.Context, name string) *Pre {
+	pc.mu.Lock()
+	if call, ok := pc.entries[name]; ok {
+		pc.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.pre
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	call := &preCall{done: make(chan struct{})}
+	pc.entries[name] = call
+	pc.mu.Unlock()
+
+	defer func() {
+		if ctx.Err() != nil {
+			pc.mu.Lock()
+			delete(pc.entries, name)
+			pc.mu.Unlock()
+		}
+		close(call.done)
+	}()
+
+	if pc.limiter != nil {
+		select {
+		case <-pc.limiter.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	call.pre = pc.lookup(name)
+
+	return call.pre
+}
+
+// stop releases the resources of the rate limiter.
+func (pc *preCache) stop() {
+	if pc.limiter != nil {
+		pc.limiter.Stop()
+	}
+}
@@ -0,0 +1,90 @@
+package release_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ParseMany(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDir(t, tempDir, map[string][]byte{
+		"Movie.2020.German.1080p.BluRay.x264-Group/movie.mkv":        []byte("movie"),
+		"Show.S01E01.German.1080p.WEB.x264-Group/show.mkv":           []byte("show"),
+		"Music.Release.2009-Group/01-my_song.mp3":                    []byte("this is a song\n"),
+		"Forbidden.2020.German.1080p.BluRay.x264-Group/movie.mkv":    []byte("movie"),
+		"Forbidden.2020.German.1080p.BluRay.x264-Group/release.nzb":  []byte("nzb"),
+		"Ignored.2020.German.1080p.BluRay.x264-Group/movie.mkv":      []byte("movie"),
+		"Ignored.2020.German.1080p.BluRay.x264-Group/Sample/one.mkv": []byte("sample"),
+	})
+
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	t.Run("all results", func(t *testing.T) {
+		roots := []string{
+			filepath.Join(tempDir, "Movie.2020.German.1080p.BluRay.x264-Group"),
+			filepath.Join(tempDir, "Show.S01E01.German.1080p.WEB.x264-Group"),
+			filepath.Join(tempDir, "Music.Release.2009-Group"),
+			filepath.Join(tempDir, "Forbidden.2020.German.1080p.BluRay.x264-Group"),
+			filepath.Join(tempDir, "Ignored.2020.German.1080p.BluRay.x264-Group"),
+			filepath.Join(tempDir, "Missing-Group"),
+		}
+
+		results := make(map[string]release.ParseResult)
+		for result := range releaseService.ParseMany(context.Background(), roots, release.ParseManyOptions{
+			Workers: 2,
+			Ignore:  []string{"Sample"},
+		}) {
+			results[filepath.Base(result.Root)] = result
+		}
+
+		require.Len(t, results, len(roots))
+
+		assert.NoError(t, results["Movie.2020.German.1080p.BluRay.x264-Group"].Err)
+		assert.Equal(t, release.Movies, results["Movie.2020.German.1080p.BluRay.x264-Group"].Info.Section)
+		assert.Equal(t, release.TV, results["Show.S01E01.German.1080p.WEB.x264-Group"].Info.Section)
+		assert.Equal(t, release.AudioMP3, results["Music.Release.2009-Group"].Info.Section)
+
+		forbidden := results["Forbidden.2020.German.1080p.BluRay.x264-Group"]
+		assert.ErrorIs(t, forbidden.Err, release.ErrForbiddenFiles)
+		require.NotNil(t, forbidden.Info)
+		assert.Equal(t, []string{"release.nzb"}, forbidden.Info.ForbiddenFiles.Names())
+
+		ignored := results["Ignored.2020.German.1080p.BluRay.x264-Group"]
+		require.NoError(t, ignored.Err)
+		assert.Equal(t, int64(5), ignored.Info.Size)
+
+		assert.ErrorIs(t, results["Missing-Group"].Err, os.ErrNotExist)
+
+		for _, result := range results {
+			assert.Positive(t, result.Duration)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		count := 0
+		for range releaseService.ParseMany(ctx, []string{tempDir, tempDir, tempDir}, release.ParseManyOptions{}) {
+			count++
+		}
+
+		assert.LessOrEqual(t, count, 1)
+	})
+
+	t.Run("no roots", func(t *testing.T) {
+		_, ok := <-releaseService.ParseMany(context.Background(), nil, release.ParseManyOptions{})
+		assert.False(t, ok)
+	})
+}
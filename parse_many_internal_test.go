@@ -0,0 +1,85 @@
+package release
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreCache_Get(t *testing.T) {
+	t.Run("deduplicate", func(t *testing.T) {
+		var calls atomic.Int32
+		pc := newPreCache(func(name string) *Pre {
+			calls.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return &Pre{Name: name}
+		}, 0)
+		defer pc.stop()
+
+		wg := sync.WaitGroup{}
+		for range 10 {
+			wg.Go(func() {
+				pre := pc.get(context.Background(), "Release-Group")
+				if assert.NotNil(t, pre) {
+					assert.Equal(t, "Release-Group", pre.Name)
+				}
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+
+		assert.Equal(t, "Other-Group", pc.get(context.Background(), "Other-Group").Name)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		const interval = 20 * time.Millisecond
+
+		pc := newPreCache(func(name string) *Pre { return nil }, interval)
+		defer pc.stop()
+
+		start := time.Now()
+		for _, name := range []string{"a", "b", "c"} {
+			assert.Nil(t, pc.get(context.Background(), name))
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 3*interval)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		pc := newPreCache(func(name string) *Pre { return &Pre{Name: name} }, time.Hour)
+		defer pc.stop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Nil(t, pc.get(ctx, "Release-Group"))
+	})
+
+	t.Run("canceled lookup is not cached", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var calls atomic.Int32
+		pc := newPreCache(func(name string) *Pre {
+			if calls.Add(1) == 1 {
+				cancel()
+				return nil
+			}
+			return &Pre{Name: name}
+		}, 0)
+		defer pc.stop()
+
+		assert.Nil(t, pc.get(ctx, "Release-Group"))
+
+		pre := pc.get(context.Background(), "Release-Group")
+		if assert.NotNil(t, pre) {
+			assert.Equal(t, "Release-Group", pre.Name)
+		}
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
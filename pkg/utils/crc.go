@@ -7,6 +7,7 @@ import (
 	"hash"
 	"hash/crc32"
 	"io"
+	"io/fs"
 	"os"
 	"runtime"
 	"sync"
@@ -39,6 +40,7 @@ type fileChunk struct {
 
 type CheckCRC struct {
 	file            string
+	fsys            fs.FS
 	wantCRC         uint32
 	bar             progress.Progress
 	useParallelRead bool
@@ -57,6 +59,12 @@ func NewCheckCRCBuilder(inputFile string, wantCRC uint32) *CheckCRCBuilder {
 	return cb
 }
 
+// WithFS reads the file from the given file system, the input file is then a path inside fsys.
+func (cb *CheckCRCBuilder) WithFS(fsys fs.FS) *CheckCRCBuilder {
+	cb.checkCRC.fsys = fsys
+	return cb
+}
+
 func (cb *CheckCRCBuilder) WithProgressBar(bar progress.Progress) *CheckCRCBuilder {
 	cb.checkCRC.bar = bar
 	return cb
@@ -81,8 +89,12 @@ func (cb *CheckCRCBuilder) Build() CheckCRC {
 	if cb.checkCRC.ctx == nil {
 		cb.checkCRC.ctx = context.Background()
 	}
+	if cb.checkCRC.fsys == nil {
+		cb.checkCRC.fsys = osFS{}
+	}
 	return CheckCRC{
 		file:            cb.checkCRC.file,
+		fsys:            cb.checkCRC.fsys,
 		wantCRC:         cb.checkCRC.wantCRC,
 		bar:             cb.checkCRC.bar,
 		useParallelRead: cb.checkCRC.useParallelRead,
@@ -94,13 +106,18 @@ func (cb *CheckCRCBuilder) Build() CheckCRC {
 func (c CheckCRC) VerifyCRC32() error {
 	var (
 		fileCRC uint32
+		writers []io.Writer
 		err     error
 	)
 
+	if c.bar != nil {
+		writers = append(writers, c.bar)
+	}
+
 	if c.useParallelRead {
-		fileCRC, err = GetCRC32Parallel(c.ctx, c.file, c.hashThreads, c.bar)
+		fileCRC, err = GetCRC32ParallelFS(c.ctx, c.fsys, c.file, c.hashThreads, writers...)
 	} else {
-		fileCRC, err = GetCRC32(c.ctx, c.file, c.bar)
+		fileCRC, err = GetCRC32FS(c.ctx, c.fsys, c.file, writers...)
 	}
 
 	if err != nil {
@@ -114,15 +131,38 @@ func (c CheckCRC) VerifyCRC32() error {
 	return nil
 }
 
+// osFS is a file system that opens regular paths on the local disk (without the restrictions of os.DirFS).
+type osFS struct{}
+
+// Open opens the named file with os.Open.
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+// Stat returns the file info of the named file with os.Stat.
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
 // GetCRC32Parallel returns the crc32 checksum of a file using multiple goroutines.
 func GetCRC32Parallel(ctx context.Context, filePath string, hashThreads int, writers ...io.Writer) (uint32, error) {
-	fileInfo, err := os.Stat(filePath)
+	return GetCRC32ParallelFS(ctx, osFS{}, filePath, hashThreads, writers...)
+}
+
+// GetCRC32ParallelFS returns the crc32 checksum of a file inside fsys using multiple goroutines.
+// If the files of fsys support neither io.ReaderAt nor io.Seeker, the checksum is calculated sequentially.
+func GetCRC32ParallelFS(ctx context.Context, fsys fs.FS, filePath string, hashThreads int, writers ...io.Writer) (uint32, error) {
+	fileInfo, err := fs.Stat(fsys, filePath)
 	if err != nil {
 		return 0, fmt.Errorf("file info: %w", err)
 	} else if fileInfo.IsDir() {
 		return 0, fmt.Errorf("file %s: directory not regular file", filePath)
 	}
 
+	if !supportsRandomAccess(fsys, filePath) {
+		return GetCRC32FS(ctx, fsys, filePath, writers...)
+	}
+
 	var numWorkers int
 	if hashThreads > 0 {
 		numWorkers = hashThreads
@@ -186,21 +226,22 @@ func GetCRC32Parallel(ctx context.Context, filePath string, hashThreads int, wri
 				defer hashPool.Put(hasher)
 				hasher.Reset()
 
-				f, err := os.Open(filePath)
+				f, err := fsys.Open(filePath)
 				if err != nil {
 					errChan <- err
 					return chunk{}
 				}
 				defer f.Close()
 
-				if _, err := f.Seek(c.startPos, io.SeekStart); err != nil {
+				section, err := sectionReader(f, c.startPos, c.chunkLength)
+				if err != nil {
 					errChan <- err
 					return chunk{}
 				}
 
 				writer := io.MultiWriter(append([]io.Writer{hasher}, writers...)...)
 
-				written, err := io.Copy(writer, io.LimitReader(f, c.chunkLength))
+				written, err := io.Copy(writer, section)
 				switch {
 				case err != nil:
 					errChan <- fmt.Errorf("%s: copy: %w", filePath, err)
@@ -246,16 +287,52 @@ func GetCRC32Parallel(ctx context.Context, filePath string, hashThreads int, wri
 	return resultCRC, nil
 }
 
+// supportsRandomAccess checks if the file can be read at arbitrary offsets.
+func supportsRandomAccess(fsys fs.FS, filePath string) bool {
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	switch f.(type) {
+	case io.ReaderAt, io.Seeker:
+		return true
+	default:
+		return false
+	}
+}
+
+// sectionReader returns a reader for the given part of the file, the file needs to implement io.ReaderAt or io.Seeker.
+func sectionReader(f fs.File, offset, length int64) (io.Reader, error) {
+	switch r := f.(type) {
+	case io.ReaderAt:
+		return io.NewSectionReader(r, offset, length), nil
+	case io.Seeker:
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.LimitReader(f, length), nil
+	default:
+		return nil, fmt.Errorf("random access: %w", errors.ErrUnsupported)
+	}
+}
+
 // GetCRC32 returns the crc32 checksum of a file.
 func GetCRC32(ctx context.Context, filePath string, writers ...io.Writer) (uint32, error) {
-	fileInfo, err := os.Stat(filePath)
+	return GetCRC32FS(ctx, osFS{}, filePath, writers...)
+}
+
+// GetCRC32FS returns the crc32 checksum of a file inside fsys.
+func GetCRC32FS(ctx context.Context, fsys fs.FS, filePath string, writers ...io.Writer) (uint32, error) {
+	fileInfo, err := fs.Stat(fsys, filePath)
 	if err != nil {
 		return 0, fmt.Errorf("file info: %w", err)
 	} else if fileInfo.IsDir() {
 		return 0, fmt.Errorf("file %s: directory not regular file", filePath)
 	}
 
-	file, err := os.Open(filePath)
+	file, err := fsys.Open(filePath)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,74 @@
+package utils_test
+
+import (
+	"context"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/f4n4t/go-release/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamFS wraps a file system and hides the random access methods of its files.
+type streamFS struct {
+	fs.FS
+}
+
+func (s streamFS) Open(name string) (fs.File, error) {
+	f, err := s.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		fs.File
+	}{f}, nil
+}
+
+func TestGetCRC32FS(t *testing.T) {
+	content := []byte(strings.Repeat("test-content\n", 1024*1024))
+	wantCRC := crc32.ChecksumIEEE(content)
+
+	fsys := fstest.MapFS{
+		"release/test.rar": {Data: content},
+		"release/sub":      {Mode: fs.ModeDir},
+	}
+
+	tests := []struct {
+		name string
+		fsys fs.FS
+	}{
+		{"map fs", fsys},
+		{"fs without random access", streamFS{fsys}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCRC, err := utils.GetCRC32FS(context.Background(), tt.fsys, "release/test.rar")
+			require.NoError(t, err)
+			assert.Equal(t, wantCRC, gotCRC)
+
+			gotCRC, err = utils.GetCRC32ParallelFS(context.Background(), tt.fsys, "release/test.rar", 4, io.Discard)
+			require.NoError(t, err)
+			assert.Equal(t, wantCRC, gotCRC)
+
+			_, err = utils.GetCRC32FS(context.Background(), tt.fsys, "release/sub")
+			assert.Error(t, err)
+
+			_, err = utils.GetCRC32FS(context.Background(), tt.fsys, "release/missing.rar")
+			assert.ErrorIs(t, err, fs.ErrNotExist)
+		})
+	}
+
+	t.Run("verify with builder", func(t *testing.T) {
+		checker := utils.NewCheckCRCBuilder("release/test.rar", wantCRC).WithFS(fsys).WithParallelRead(true).Build()
+		assert.NoError(t, checker.VerifyCRC32())
+
+		checker = utils.NewCheckCRCBuilder("release/test.rar", wantCRC+1).WithFS(fsys).Build()
+		assert.ErrorIs(t, checker.VerifyCRC32(), utils.ErrCRCMismatch)
+	})
+}
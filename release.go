@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -52,7 +54,7 @@ var Regexes = struct {
 
 var (
 	// mediaInfoSections contains the sections for which mediainfo will be generated.
-	mediaInfoSections = []Section{TV, TVPack, Movies, AudioVideo, Sport, AudioBooks, AudioFLAC, AudioMP3}
+	mediaInfoSections = []Section{TV, TVPack, Movies, AudioVideo, Sport, Anime, AudioBooks, AudioFLAC, AudioMP3}
 
 	// ForbiddenExtensions holds all the forbidden extensions.
 	ForbiddenExtensions = []string{".nzb", ".par2", ".url", ".html", ".srr", ".srs"}
@@ -86,17 +88,34 @@ const (
 type Service struct {
 	log              zerolog.Logger
 	sportPatterns    []string
+	sportMatcher     *regexp.Regexp
 	skipPre          bool
 	skipMediaInfo    bool
 	parallelFileRead ParallelFileRead
 	hashThreads      int
 	preInfo          *Pre
-	ctx              context.Context
+	ruleset          *Ruleset
+	ignoreFile       string
+	symlinkPolicy    SymlinkPolicy
+	duplicateCheck   DuplicateCheck
+	episodeCheck     EpisodeCheck
+	fixIndex         *DupeIndex
+	// sectionClassifiers are asked before the builtin section detection, fallbackClassifiers after it.
+	sectionClassifiers  []SectionClassifier
+	fallbackClassifiers []SectionClassifier
+	customSections      map[Section]bool
+	sectionRules        *SectionRules
+	ctx                 context.Context
+	// preCache and mediaInfoLimit are shared between the workers of ParseMany.
+	preCache       *preCache
+	mediaInfoLimit chan struct{}
 }
 
 // ServiceBuilder is a builder for the Service.
 type ServiceBuilder struct {
 	service Service
+	// err holds the errors of the builder methods, it is returned by Build.
+	err error
 }
 
 // NewServiceBuilder creates a new ServiceBuilder.
@@ -106,9 +125,10 @@ func NewServiceBuilder() *ServiceBuilder {
 	return sb
 }
 
-// WithSportPatterns sets the sport patterns.
+// WithSportPatterns sets the sport patterns, which are checked in addition to the embedded patterns.
+// A release name is a sport release if it starts with any pattern followed by a separator.
 func (s *ServiceBuilder) WithSportPatterns(patterns []string) *ServiceBuilder {
-	s.service.sportPatterns = patterns
+	s.service.sportPatterns = slices.Clone(patterns)
 	return s
 }
 
@@ -134,6 +154,49 @@ func (s *ServiceBuilder) WithPreInfo(preInfo *Pre) *ServiceBuilder {
 	return s
 }
 
+// WithRuleset sets the ruleset used for the forbidden files, defaults to DefaultRuleset().
+// Only file rules without a when condition or with a when condition on the name (name, name_length, anime
+// and not) are checked while parsing, violations with SeverityError are added to the forbidden files and
+// all others are logged.
+func (s *ServiceBuilder) WithRuleset(ruleset *Ruleset) *ServiceBuilder {
+	s.service.ruleset = ruleset
+	return s
+}
+
+// WithIgnoreFile sets an ignore file with gitignore-style patterns (see IgnoreMatcher) that is used for every parse.
+// Its patterns are applied before the ReleaseIgnoreFile in the release root and the patterns passed to Parse.
+func (s *ServiceBuilder) WithIgnoreFile(filePath string) *ServiceBuilder {
+	s.service.ignoreFile = filePath
+	return s
+}
+
+// WithSymlinkPolicy sets how symlinks inside the release are handled, defaults to SymlinkRecord.
+func (s *ServiceBuilder) WithSymlinkPolicy(policy SymlinkPolicy) *ServiceBuilder {
+	s.service.symlinkPolicy = policy
+	return s
+}
+
+// WithDuplicateCheck enables the search for hardlinks and files with identical content inside the release,
+// defaults to DuplicateCheckDisabled. The content of all files with the same size is compared.
+func (s *ServiceBuilder) WithDuplicateCheck(check DuplicateCheck) *ServiceBuilder {
+	s.service.duplicateCheck = check
+	return s
+}
+
+// WithEpisodeCheck enables the check of a TVPack or an anime batch for missing and duplicate episodes, defaults to
+// EpisodeCheckDisabled. Use EpisodeCheckStrict to reject incomplete season packs.
+func (s *ServiceBuilder) WithEpisodeCheck(check EpisodeCheck) *ServiceBuilder {
+	s.service.episodeCheck = check
+	return s
+}
+
+// WithFixIndex sets a local index of releases, it is searched before the pre information for the parent release
+// of a fix (see ResolveFixParent).
+func (s *ServiceBuilder) WithFixIndex(index *DupeIndex) *ServiceBuilder {
+	s.service.fixIndex = index
+	return s
+}
+
 // WithContext sets the context for the service.
 func (s *ServiceBuilder) WithContext(ctx context.Context) *ServiceBuilder {
 	s.service.ctx = ctx
@@ -164,21 +227,48 @@ func (s *ServiceBuilder) WithHashThreads(i int) *ServiceBuilder {
 	return s
 }
 
-// Build creates a new Service from the builder.
-func (s *ServiceBuilder) Build() *Service {
+// Build creates a new Service from the builder. It returns an error if a sport pattern or the section rules
+// are invalid.
+func (s *ServiceBuilder) Build() (*Service, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	sportMatcher := defaultSportMatcher()
+	if len(s.service.sportPatterns) > 0 {
+		var err error
+		if sportMatcher, err = compileSportMatcher(s.service.sportPatterns); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.service.ctx == nil {
 		s.service.ctx = context.Background()
 	}
-	return &Service{
-		log:              s.service.log,
-		sportPatterns:    s.service.sportPatterns,
-		skipPre:          s.service.skipPre,
-		skipMediaInfo:    s.service.skipMediaInfo,
-		parallelFileRead: s.service.parallelFileRead,
-		hashThreads:      s.service.hashThreads,
-		preInfo:          s.service.preInfo,
-		ctx:              s.service.ctx,
+	if s.service.ruleset == nil {
+		s.service.ruleset = defaultRuleset()
 	}
+	return &Service{
+		log:                 s.service.log,
+		sportPatterns:       slices.Clone(s.service.sportPatterns),
+		sportMatcher:        sportMatcher,
+		skipPre:             s.service.skipPre,
+		skipMediaInfo:       s.service.skipMediaInfo,
+		parallelFileRead:    s.service.parallelFileRead,
+		hashThreads:         s.service.hashThreads,
+		preInfo:             s.service.preInfo,
+		ruleset:             s.service.ruleset,
+		ignoreFile:          s.service.ignoreFile,
+		symlinkPolicy:       s.service.symlinkPolicy,
+		duplicateCheck:      s.service.duplicateCheck,
+		episodeCheck:        s.service.episodeCheck,
+		fixIndex:            s.service.fixIndex,
+		sectionClassifiers:  slices.Clone(s.service.sectionClassifiers),
+		fallbackClassifiers: slices.Clone(s.service.fallbackClassifiers),
+		customSections:      maps.Clone(s.service.customSections),
+		sectionRules:        s.service.sectionRules,
+		ctx:                 s.service.ctx,
+	}, nil
 }
 
 // Info represents the main struct with all the additional information.
@@ -187,16 +277,26 @@ type Info struct {
 	ArchiveCount int `json:"archive_count"`
 	// BiggestFile is the largest file found in the release.
 	BiggestFile *dtree.Node `json:"-"`
-	// Episodes is a slice with all matched Episodes (only media files).
+	// Discs holds the discs of a multi-disc release (CD1, Disc1, DVD1, ...) sorted by their number.
+	Discs []Disc `json:"discs,omitempty"`
+	// Episodes is a slice with all matched Episodes (only media files), sorted by season and number.
 	Episodes []Episode `json:"episodes"`
+	// DuplicateEpisodes holds the episodes found in more than one file, with the file of the duplicate.
+	DuplicateEpisodes []Episode `json:"duplicate_episodes,omitempty"`
 	// Extensions is a map with all the found file extensions and their count (all extensions are converted to lowercase).
 	Extensions map[string]int `json:"extensions"`
 	// BaseDir is the base directory path of the release.
 	BaseDir string `json:"base_dir"`
 	// Root is the root node of the directory tree.
 	Root *dtree.Node `json:"-"`
-	// ForbiddenFiles is a slice with all the files that are either empty files or folders, have bad chars or matched the ForbiddenExtensions slice.
+	// ForbiddenFiles is a slice with all the files that are empty folders or violate a file rule of the ruleset (see DefaultRuleset).
 	ForbiddenFiles ForbiddenFiles `json:"-"`
+	// DuplicateFiles holds the groups of hardlinks and files with identical content (see WithDuplicateCheck).
+	DuplicateFiles []DuplicateGroup `json:"duplicate_files,omitempty"`
+	// Symlinks holds all symlinks of the release that were recorded or followed (see SymlinkPolicy).
+	Symlinks []Symlink `json:"symlinks,omitempty"`
+	// Report holds the findings of Parse, every forbidden file and all rule violations with a lower severity.
+	Report *Report `json:"report"`
 	// Group is the name of the release group (final part of the release after the -).
 	Group string `json:"group"`
 	// ImdbID is the parsed IMDB ID from the NFO file.
@@ -209,6 +309,8 @@ type Info struct {
 	MediaInfoJSON []byte `json:"-"`
 	// Name is the release name (basename of directory or file without extension).
 	Name string `json:"name"`
+	// ReleaseName holds all the tokens parsed from the release name.
+	ReleaseName `json:"release_name"`
 	// PreInfo is a pointer to the Pre information if something is found.
 	PreInfo *Pre `json:"-"`
 	// ProductTitle is the title without all the additional meta-tags.
@@ -223,14 +325,26 @@ type Info struct {
 	Size int64 `json:"size"`
 	// Language is the parsed language tag from the release name.
 	Language string `json:"language"`
+	// Languages holds the audio and subtitle languages of the name merged with the tracks of the mediainfo.
+	Languages Languages `json:"languages"`
 	// TagResolution is the parsed resolution tag from the release name.
 	TagResolution Resolution `json:"tag_resolution"`
+	// VideoFormat is the video format of the name merged with the video track of the mediainfo.
+	VideoFormat VideoFormat `json:"video_format"`
+	// Anime holds the tokens of an anime or fansub name, nil for all other releases (see ParseAnimeName).
+	Anime *AnimeName `json:"anime,omitempty"`
+	// FixType is the type of fix parsed from the release name, NoFix for all other releases.
+	FixType FixType `json:"fix_type,omitempty"`
+	// FixParent is the name of the release the fix belongs to, empty if it wasn't found (see ResolveFixParent).
+	FixParent string `json:"fix_parent,omitempty"`
 	// IsSingleFile is true when the root is a file rather than a directory.
 	IsSingleFile bool `json:"single_file"`
 	// NFO holds the name and content of an NFO file if one is found.
 	NFO *NFOFile `json:"-"`
 	// parents map is used internally to build the directory tree.
 	parents map[string]*dtree.Node
+	// files gives access to the file contents of the release.
+	files releaseFS
 }
 
 func (i *Info) HasNuke() bool {
@@ -283,6 +397,20 @@ func (ff *ForbiddenFiles) Names() []string {
 	return forbiddenList
 }
 
+// addForbiddenFile adds a new file to the forbidden files and the report.
+func (i *Info) addForbiddenFile(code FindingCode, fullPath string, fileInfo *dtree.FileInfo, fileError error) {
+	i.ForbiddenFiles.addFile(fullPath, fileInfo, fileError)
+	i.addFinding(code, SeverityError, fullPath, fileError)
+}
+
+// addFinding adds a finding to the report, the report is created with the first finding if the Info has none.
+func (i *Info) addFinding(code FindingCode, severity Severity, path string, err error) {
+	if i.Report == nil {
+		i.Report = &Report{}
+	}
+	i.Report.add(code, severity, path, err)
+}
+
 // addFile adds a new file to the forbidden files slice.
 func (ff *ForbiddenFiles) addFile(fullPath string, fileInfo *dtree.FileInfo, fileError error) {
 	*ff = append(*ff, ForbiddenFile{
@@ -292,18 +420,6 @@ func (ff *ForbiddenFiles) addFile(fullPath string, fileInfo *dtree.FileInfo, fil
 	})
 }
 
-// isForbidden checks for forbidden extensions
-func isForbidden(fi *dtree.FileInfo) bool {
-	return slices.Contains(ForbiddenExtensions, fi.Extension)
-}
-
-// Episode represents a single episode in a series.
-type Episode struct {
-	Number int         `json:"number"`
-	Name   string      `json:"name"`
-	File   *dtree.Node `json:"-"`
-}
-
 // NFOFile contains a single nfo file with content and filename.
 type NFOFile struct {
 	Name    string
@@ -311,20 +427,96 @@ type NFOFile struct {
 }
 
 // Parse processes a directory structure, extracts information, and builds a tree representation of its contents.
+// Forbidden files don't stop the parsing, the Info is returned together with a *ReportError that holds all findings.
+// The ignore patterns are gitignore-style patterns matched case-insensitive, see IgnoreMatcher.
 func (s *Service) Parse(root string, ignore ...string) (*Info, error) {
-	info, err := s.initReleaseInfo(root)
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("get absolute path: %w", err)
+	}
+
+	baseDir := filepath.Dir(absRoot)
+	files := releaseFS{fsys: os.DirFS(baseDir), base: baseDir}
+
+	return s.parse(files, filepath.ToSlash(filepath.Base(absRoot)), ignore)
+}
+
+// ParseFS works like Parse, but reads the release from the given file system.
+// The root is a slash separated path inside fsys (see fs.ValidPath), the FullPath of every node is then also a path
+// inside fsys. Mediainfo is only generated for releases on the local disk, so it is skipped here.
+func (s *Service) ParseFS(fsys fs.FS, root string, ignore ...string) (*Info, error) {
+	if !fs.ValidPath(root) {
+		return nil, &fs.PathError{Op: "parse", Path: root, Err: fs.ErrInvalid}
+	}
+
+	return s.parse(releaseFS{fsys: fsys}, root, ignore)
+}
+
+// ignoreMatcher compiles the patterns of the ignore file of the service, the ReleaseIgnoreFile in the release root
+// and the given patterns in this order, so the later ones win.
+func (s *Service) ignoreMatcher(info *Info, ignore []string) (*IgnoreMatcher, error) {
+	var patterns []string
+
+	if s.ignoreFile != "" {
+		filePatterns, err := LoadIgnoreFile(s.ignoreFile)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, filePatterns...)
+	}
+
+	if !info.IsSingleFile && info.files.hasContent() {
+		data, err := info.files.readFile(info.files.join(info.BaseDir, ReleaseIgnoreFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read %s: %w", ReleaseIgnoreFile, err)
+		}
+		patterns = append(patterns, ParseIgnoreFile(data)...)
+	}
+
+	matcher, err := NewIgnoreMatcher(append(patterns, ignore...), true)
+	if err != nil {
+		return nil, fmt.Errorf("check ignore list: %w", err)
+	}
+
+	return matcher, nil
+}
+
+// parse walks the release root inside the given file system and collects all information.
+func (s *Service) parse(files releaseFS, root string, ignore []string) (*Info, error) {
+	info, err := s.initReleaseInfo(files, root)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreMatcher, err := s.ignoreMatcher(info, ignore)
 	if err != nil {
 		return nil, err
 	}
 
-	walkFunc := func(path string, fileInfo fs.FileInfo, walkErr error) error {
+	var walkFunc fs.WalkDirFunc
+
+	walkFunc = func(fsPath string, entry fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
-		return s.processPath(info, path, dtree.FileInfoFromInterface(fileInfo), ignore)
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case fileInfo.Mode()&fs.ModeSymlink != 0:
+			return s.processSymlink(info, fsPath, fileInfo, ignoreMatcher, walkFunc)
+
+		case isSpecialFile(fileInfo.Mode()):
+			return s.processSpecialFile(info, files.fullPath(fsPath), dtree.FileInfoFromInterface(fileInfo), ignoreMatcher)
+		}
+
+		return s.processPath(info, files.fullPath(fsPath), dtree.FileInfoFromInterface(fileInfo), ignoreMatcher)
 	}
 
-	if err := filepath.Walk(info.BaseDir, walkFunc); err != nil {
+	if err := fs.WalkDir(files.fsys, root, walkFunc); err != nil {
 		return nil, err
 	}
 
@@ -336,6 +528,14 @@ func (s *Service) Parse(root string, ignore ...string) (*Info, error) {
 
 	s.checkForEmptySubfolders(info, info.Root)
 
+	info.Discs = findDiscs(info.Root)
+
+	if s.duplicateCheck != DuplicateCheckDisabled && files.hasContent() {
+		if err := s.findDuplicateFiles(info); err != nil {
+			return nil, fmt.Errorf("find duplicate files: %w", err)
+		}
+	}
+
 	// sort media files by name
 	sort.Slice(info.MediaFiles, func(i, j int) bool {
 		return info.MediaFiles[i].Info.Name < info.MediaFiles[j].Info.Name
@@ -344,26 +544,45 @@ func (s *Service) Parse(root string, ignore ...string) (*Info, error) {
 	if s.preInfo != nil {
 		info.PreInfo = s.preInfo
 	} else if !s.skipPre {
-		info.PreInfo = s.GetPre(info.Name)
+		info.PreInfo = s.searchPre(info.Name)
 	}
 
-	info.Section = s.ParseSection(info.Name, info.PreInfo)
+	if info.FixType != NoFix {
+		info.FixParent, _ = s.ResolveFixParent(info.Name)
+		s.checkFixContent(info)
+
+		// fixes belong to the section of their parent release
+		info.Section = s.ParseSection(info.fixSectionName(), info.PreInfo)
+	} else {
+		info.Section = s.ParseSection(info.Name, info.PreInfo)
+	}
 
 	// search for episode numbers
-	if info.Section == TVPack || info.Section == TV {
-		info.Episodes = getEpisodes(info.MediaFiles, info.Root)
+	if info.Section == TVPack || info.Section == TV || info.Section == Anime {
+		info.Episodes, info.DuplicateEpisodes = getEpisodes(info.MediaFiles, info.Root)
 
 		if !s.skipPre && info.PreInfo == nil && len(info.Episodes) > 1 {
 			firstChild := info.Root.Children[0]
 			if firstChild.Info.IsDir {
 				s.log.Debug().Str("name", firstChild.Info.Name).
 					Msg("trying to search for pre information with sub folder name")
-				info.PreInfo = s.GetPre(firstChild.Info.Name)
+				info.PreInfo = s.searchPre(firstChild.Info.Name)
 			}
 		}
 
-		if len(info.Episodes) < 2 {
+		switch {
+		case info.Section == Anime:
+			// anime releases keep their section, batches are checked like a TVPack
+		case len(info.Episodes) < 2:
 			info.Section = TV
+		case info.Section == TV && isEpisodeBatch(info.Episodes):
+			// daily shows and anime batches have no season in the name
+			info.Section = TVPack
+		}
+
+		isPack := info.Section == TVPack || info.Section == Anime && isEpisodeBatch(info.Episodes)
+		if isPack && s.episodeCheck != EpisodeCheckDisabled {
+			s.checkEpisodes(info)
 		}
 	}
 
@@ -378,11 +597,14 @@ func (s *Service) Parse(root string, ignore ...string) (*Info, error) {
 		info.checkForSectionByExtensions()
 	}
 
-	if !s.skipMediaInfo && slices.Contains(mediaInfoSections, info.Section) {
+	if !s.skipMediaInfo && info.files.isLocal() && s.hasMediaInfo(info.Section) {
 		s.tryGenerateMediaInfo(info)
 	}
 
 	if info.MediaInfo != nil {
+		info.Languages = info.Languages.Merge(info.MediaInfo.Languages())
+		info.VideoFormat = info.VideoFormat.Merge(info.MediaInfo.VideoFormat())
+
 		if info.ImdbID == 0 {
 			info.ImdbID = info.MediaInfo.GetImdbID()
 		}
@@ -397,8 +619,15 @@ func (s *Service) Parse(root string, ignore ...string) (*Info, error) {
 		Any("Section", info.Section).
 		Msg("parsed release")
 
-	if len(info.ForbiddenFiles) > 0 {
-		return info, ErrForbiddenFiles
+	// the report error matches ErrForbiddenFiles and the errors of all forbidden files, missing episodes alone
+	// are an incomplete pack
+	sentinel := ErrForbiddenFiles
+	if len(info.ForbiddenFiles) == 0 {
+		sentinel = ErrIncompletePack
+	}
+
+	if err := info.Report.err(sentinel); err != nil {
+		return info, err
 	}
 
 	return info, nil
@@ -409,6 +638,10 @@ func (s *Service) tryGenerateMediaInfo(info *Info) {
 	var mediaFile *dtree.Node
 
 	switch {
+	case len(info.Discs) > 0:
+		// mediainfo is generated from the first disc
+		mediaFile = info.Discs[0].mediaInfoFile()
+
 	case info.ArchiveCount > 1:
 		mediaFile, _ = getRarForMediaInfo(info.Root)
 
@@ -438,6 +671,12 @@ func (s *Service) tryGenerateMediaInfo(info *Info) {
 		}
 	}
 
+	done, ok := s.acquireMediaInfo()
+	if !ok {
+		return
+	}
+	defer done()
+
 	s.log.Debug().Str("mediaFile", mediaFile.FullPath).Msg("generating mediainfo...")
 
 	mediaInfoJSON, mediaInfo, err := GenerateMediaInfo(s.ctx, mediaFile.FullPath)
@@ -481,7 +720,7 @@ func (s *Service) checkForEmptySubfolders(info *Info, node *dtree.Node) {
 		if child.Info.IsDir {
 			if len(child.Children) == 0 {
 				s.log.Error().Str("folder", child.FullPath).Err(ErrEmptyFolder).Msg("")
-				info.ForbiddenFiles.addFile(child.FullPath, child.Info, ErrEmptyFolder)
+				info.addForbiddenFile(CodeEmptyFolder, child.FullPath, child.Info, ErrEmptyFolder)
 			} else {
 				s.checkForEmptySubfolders(info, child)
 			}
@@ -489,49 +728,46 @@ func (s *Service) checkForEmptySubfolders(info *Info, node *dtree.Node) {
 	}
 }
 
-// initReleaseInfo initializes a new Info struct from a file or directory path.
-// It resolves the absolute path, extracts essential metadata like name, group, and year,
-// and prepares the basic structure for further processing.
-func (s *Service) initReleaseInfo(root string) (*Info, error) {
-	absRoot, err := filepath.Abs(root)
-	if err != nil {
-		return nil, fmt.Errorf("get absolute path: %w", err)
-	}
-
-	rootFileInfo, err := os.Stat(absRoot)
+// initReleaseInfo initializes a new Info struct from a file or directory path inside the release file system.
+// It extracts essential metadata like name, group, and year, and prepares the basic structure for further processing.
+func (s *Service) initReleaseInfo(files releaseFS, root string) (*Info, error) {
+	rootFileInfo, err := fs.Stat(files.fsys, root)
 	if err != nil {
 		return nil, fmt.Errorf("get file info: %w", err)
 	}
 
-	rlsName := filepath.Base(absRoot)
+	rlsName := path.Base(root)
 	isSingleFile := !rootFileInfo.IsDir()
 
 	if isSingleFile {
 		// remove extension from name
-		rlsName = strings.TrimSuffix(rlsName, filepath.Ext(rlsName))
+		rlsName = strings.TrimSuffix(rlsName, path.Ext(rlsName))
 	}
 
+	releaseName := ParseName(rlsName)
+
 	info := &Info{
 		parents:       make(map[string]*dtree.Node),
 		Extensions:    make(map[string]int),
-		BaseDir:       absRoot,
+		BaseDir:       files.fullPath(root),
 		Name:          rlsName,
+		ReleaseName:   releaseName,
+		Group:         releaseName.Group.Value,
 		Language:      ParseLanguage(rlsName),
+		Languages:     ParseLanguages(rlsName),
 		TagResolution: ParseResolution(rlsName),
+		VideoFormat:   ParseVideoFormat(rlsName),
 		ProductTitle:  cleanTitle(rlsName),
+		ProductYear:   releaseName.Year.Int(),
+		FixType:       ParseFixType(rlsName),
 		IsSingleFile:  isSingleFile,
+		Report:        &Report{},
+		files:         files,
 	}
 
-	if m := Regexes.Group.FindStringSubmatch(info.Name); m != nil {
-		info.Group = m[1]
-	}
-
-	if m := Regexes.Year.FindAllStringSubmatch(info.Name, -1); m != nil {
-		if len(m) > 1 {
-			info.ProductYear, _ = strconv.Atoi(m[1][1])
-		} else {
-			info.ProductYear, _ = strconv.Atoi(m[0][1])
-		}
+	if an, ok := ParseAnimeName(rlsName); ok {
+		info.Anime = &an
+		info.ProductTitle = an.Title.Value
 	}
 
 	return info, nil
@@ -577,25 +813,23 @@ func cleanTitle(releaseName string) string {
 }
 
 // processPath processes a given file or directory path, handling errors, skips, forbidden criteria, and context updates.
-func (s *Service) processPath(info *Info, path string, fileInfo *dtree.FileInfo, ignore []string) error {
-	if len(ignore) > 0 {
-		skip, err := s.checkIgnoreList(info, path, fileInfo, ignore)
-		if err != nil {
-			return fmt.Errorf("check ignore list: %w", err)
-		}
-		switch skip {
-		case skipFile:
-			return nil
-		case skipDir:
-			return fs.SkipDir
-		default:
-			// skipNothing
-		}
+func (s *Service) processPath(info *Info, path string, fileInfo *dtree.FileInfo, ignore *IgnoreMatcher) error {
+	skip, err := s.checkIgnoreList(info, path, fileInfo, ignore)
+	if err != nil {
+		return fmt.Errorf("check ignore list: %w", err)
+	}
+	switch skip {
+	case skipFile:
+		return nil
+	case skipDir:
+		return fs.SkipDir
+	default:
+		// skipNothing
 	}
 
-	if Regexes.BadChars.MatchString(fileInfo.Name) {
-		info.ForbiddenFiles.addFile(path, fileInfo, ErrForbiddenCharacters)
-		s.log.Error().Str("name", fileInfo.Name).Err(ErrForbiddenCharacters).Msg("")
+	// the rules for files are checked together with the extension
+	if fileInfo.IsDir {
+		s.checkFileRules(info, path, fileInfo)
 	}
 
 	node := &dtree.Node{
@@ -609,12 +843,6 @@ func (s *Service) processPath(info *Info, path string, fileInfo *dtree.FileInfo,
 		info.Size += fileInfo.Size
 		info.Extensions[strings.ToLower(fileInfo.Extension)] += 1
 
-		// empty file
-		if fileInfo.Size == 0 {
-			info.ForbiddenFiles.addFile(path, fileInfo, ErrEmptyFile)
-			s.log.Error().Str("name", fileInfo.Name).Err(ErrEmptyFile).Msg("")
-		}
-
 		if err := s.checkFileExtension(info, node); err != nil {
 			return fmt.Errorf("check file extension: %w", err)
 		}
@@ -627,27 +855,57 @@ func (s *Service) processPath(info *Info, path string, fileInfo *dtree.FileInfo,
 	return nil
 }
 
+// checkFileRules checks the file against the file rules of the ruleset, which are the bad characters, empty files
+// and forbidden extensions by default. Violations with SeverityError are added to the forbidden files, all
+// violations are added to the report.
+func (s *Service) checkFileRules(info *Info, path string, fileInfo *dtree.FileInfo) {
+	ruleset := s.ruleset
+	if ruleset == nil {
+		ruleset = defaultRuleset()
+	}
+
+	for _, violation := range ruleset.fileViolations(info, info.relPath(path), fileInfo, path) {
+		if violation.Severity != SeverityError {
+			s.log.Warn().Str("name", fileInfo.Name).Str("rule", violation.RuleID).Msg(violation.Description)
+			info.addFinding(FindingCode(violation.RuleID), violation.Severity, path, violation)
+			continue
+		}
+
+		// builtin rules keep their sentinel error
+		fileErr := error(violation)
+		if !errors.Is(violation.Err, ErrRuleViolation) {
+			fileErr = violation.Err
+		}
+
+		info.addForbiddenFile(FindingCode(violation.RuleID), path, fileInfo, fileErr)
+		s.log.Error().Str("name", fileInfo.Name).Err(fileErr).Msg("")
+	}
+}
+
 // checkIgnoreList evaluates if a file or directory should be skipped based on the provided ignore-patterns.
-func (s *Service) checkIgnoreList(info *Info, path string, fileInfo *dtree.FileInfo, ignore []string) (skipType, error) {
-	var (
-		relPath string
-		err     error
-	)
+// The release root is never skipped, the ReleaseIgnoreFile in the release root always.
+func (s *Service) checkIgnoreList(info *Info, path string, fileInfo *dtree.FileInfo, ignore *IgnoreMatcher) (skipType, error) {
+	var relPath string
 
 	if info.IsSingleFile {
 		relPath = filepath.Base(fileInfo.Name)
 	} else {
-		relPath, err = filepath.Rel(info.BaseDir, path)
+		rel, err := filepath.Rel(info.BaseDir, path)
 		if err != nil {
 			return skipNothing, fmt.Errorf("get relative path: %w", err)
 		}
+		if rel == "." {
+			return skipNothing, nil
+		}
+		relPath = filepath.ToSlash(rel)
 	}
 
-	skip, err := canSkip(relPath, ignore, true)
-	if err != nil {
-		return skipNothing, fmt.Errorf("check ignore list: %w", err)
+	if !info.IsSingleFile && relPath == ReleaseIgnoreFile && !fileInfo.IsDir {
+		return skipFile, nil
 	}
 
+	skip := ignore.Match(relPath, fileInfo.IsDir)
+
 	if !skip {
 		return skipNothing, nil
 	}
@@ -665,26 +923,24 @@ func (s *Service) checkIgnoreList(info *Info, path string, fileInfo *dtree.FileI
 // maxNFOSize is the maximum size of a nfo file that will be parsed.
 const maxNFOSize int64 = 10 * 1024 * 1024 // 10MB
 
-// checkFileExtension processes files based on their extension and updates the context.
+// checkFileExtension checks the file rules and processes files based on their extension and updates the context.
 // For .nfo files, only the first one is stored, but later ones are still checked for missing IMDB IDs.
 func (s *Service) checkFileExtension(info *Info, node *dtree.Node) error {
-	switch {
-	case isForbidden(node.Info):
-		info.ForbiddenFiles.addFile(node.FullPath, node.Info, ErrForbiddenExtension)
-		s.log.Error().Str("name", node.Info.Name).Err(ErrForbiddenExtension).Msg("")
+	s.checkFileRules(info, node.FullPath, node.Info)
 
+	switch {
 	case node.Info.Extension == ".sfv":
 		info.SfvCount++
 
 	case node.Info.Extension == ".nfo":
-		if node.Info.Size == 0 || (info.ImdbID > 0 && info.NFO != nil) {
+		if node.Info.Size == 0 || !info.files.hasContent() || (info.ImdbID > 0 && info.NFO != nil) {
 			break
 		} else if node.Info.Size > maxNFOSize {
 			s.log.Warn().Msg("nfo is bigger than 10MB, skip parsing")
 			break
 		}
 
-		nfoContent, err := os.ReadFile(node.FullPath)
+		nfoContent, err := info.files.readFile(node.FullPath)
 		if err != nil {
 			return fmt.Errorf("read nfo file: %w", err)
 		}
@@ -713,35 +969,6 @@ func (s *Service) checkFileExtension(info *Info, node *dtree.Node) error {
 	return nil
 }
 
-// canSkip is a helper function to check if the file or folder can be ignored.
-func canSkip(path string, pattern []string, ignoreCase bool) (bool, error) {
-	for _, p := range pattern {
-		var name string
-
-		if strings.Contains(p, string(filepath.Separator)) {
-			name = path
-		} else {
-			name = filepath.Base(path)
-		}
-
-		if ignoreCase {
-			p = strings.ToLower(p)
-			name = strings.ToLower(name)
-		}
-
-		match, err := filepath.Match(p, name)
-		if err != nil {
-			return false, fmt.Errorf("pattern %s: %w", p, err)
-		}
-
-		if match {
-			return true, nil
-		}
-	}
-
-	return false, nil
-}
-
 // HasMetaFiles checks extensions against Regexes.MetaFiles.
 func (rel *Info) HasMetaFiles(ignore ...string) bool {
 	for e := range rel.Extensions {
@@ -775,13 +1002,15 @@ func (rel *Info) HasExtensions(extensions ...string) bool {
 	return true
 }
 
-// HasAnyLanguage checks if any of the given languages are found.
+// HasAnyLanguage checks if any of the given languages are found in the language tag, the audio languages
+// of the name or the audio tracks of the mediainfo. Names and codes are normalized (see NormalizeLanguage),
+// so "german", "ger" and "de" are the same.
 func (rel *Info) HasAnyLanguage(languages ...string) bool {
-	exists := slices.ContainsFunc(languages, func(s string) bool {
-		return strings.EqualFold(s, rel.Language)
-	})
+	if rel.Languages.HasAnyAudio(languages...) {
+		return true
+	}
 
-	if exists {
+	if rel.Language != "" && containsLanguage([]string{NormalizeLanguage(rel.Language)}, languages) {
 		return true
 	}
 
@@ -796,7 +1025,7 @@ func (rel *Info) HasAnyLanguage(languages ...string) bool {
 
 // HasGermanLanguage checks if release has german language.
 func (rel *Info) HasGermanLanguage() bool {
-	return rel.HasAnyLanguage("de", "german", "deutsch")
+	return rel.HasAnyLanguage("de")
 }
 
 var (
@@ -832,103 +1061,6 @@ func getRarForMediaInfo(startNode *dtree.Node) (*dtree.Node, error) {
 	return nil, errors.New("no fitting rar file found")
 }
 
-// getEpisodes processes a list of media files and a node, extracting and sorting episodes by their numbers.
-// If more than 1 episode has already been found in mediaFiles, the root node can be skipped, otherwise search in
-// the subfolders (rootNode).
-// Note: only call this function if the root node is a directory and not nil.
-// Precondition: mediaFiles and rootNode must not be nil.
-func getEpisodes(mediaFiles []*dtree.Node, rootNode *dtree.Node) []Episode {
-	var episodes []Episode
-
-	for _, nodes := range [][]*dtree.Node{mediaFiles, rootNode.Children} {
-		for _, file := range nodes {
-			if slices.Contains(PictureExtensions, file.Info.Extension) ||
-				slices.Contains(AudioExtensions, file.Info.Extension) {
-				continue
-			}
-
-			extractedEpisode := extractEpisodesFromFile(file)
-			episodes = append(episodes, extractedEpisode...)
-		}
-
-		if len(episodes) > 1 {
-			// we already found our episodes
-			break
-		}
-	}
-
-	episodes = removeDuplicateEpisodes(episodes)
-
-	// sort episodes by number
-	sort.Slice(episodes, func(i, j int) bool {
-		return episodes[i].Number < episodes[j].Number
-	})
-
-	return episodes
-}
-
-var (
-	episodePattern      = regexp.MustCompile(`(?i)[ed](\d{1,3})`)
-	episodeRangePattern = regexp.MustCompile(`(?i)[ed](\d{1,3})-[ed](\d{1,3})`)
-)
-
-// extractEpisodesFromFile parses a Node's file name to extract episode numbers and creates corresponding Episode objects.
-func extractEpisodesFromFile(node *dtree.Node) []Episode {
-	results := make([]Episode, 0)
-	fileName := node.Info.Name
-	episodeNumbers := make(map[int]struct{}) // To avoid duplicates
-
-	// Check for ranges first
-	for _, match := range episodeRangePattern.FindAllStringSubmatch(fileName, -1) {
-		start, err1 := strconv.Atoi(match[1])
-		end, err2 := strconv.Atoi(match[2])
-
-		if err1 == nil && err2 == nil && start <= end {
-			for i := start; i <= end; i++ {
-				episodeNumbers[i] = struct{}{}
-			}
-		}
-	}
-
-	// Check for individual episodes
-	for _, match := range episodePattern.FindAllStringSubmatch(fileName, -1) {
-		if episode, err := strconv.Atoi(match[1]); err == nil {
-			episodeNumbers[episode] = struct{}{}
-		}
-	}
-
-	for episode := range episodeNumbers {
-		mediaFile := node.GetBiggest(nil)
-		results = append(results, Episode{
-			Number: episode,
-			File:   mediaFile,
-			Name:   mediaFile.Info.Name,
-		})
-	}
-
-	// sort episodes by number
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Number < results[j].Number
-	})
-
-	return results
-}
-
-// removeDuplicateEpisodes removes every duplicate episode from the episode slice.
-func removeDuplicateEpisodes(episodes []Episode) []Episode {
-	episodeNumbers := make(map[int]struct{})
-	list := []Episode{}
-
-	for _, episode := range episodes {
-		if _, ok := episodeNumbers[episode.Number]; !ok {
-			episodeNumbers[episode.Number] = struct{}{}
-			list = append(list, episode)
-		}
-	}
-
-	return list
-}
-
 func (i *Info) checkForSectionByExtensions() {
 	switch {
 	case i.Section != Unknown:
@@ -2,9 +2,11 @@ package release_test
 
 import (
 	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/f4n4t/go-dtree"
 	"github.com/f4n4t/go-release"
@@ -473,7 +475,8 @@ func TestParse(t *testing.T) {
 			// disable logger
 			zerolog.SetGlobalLevel(zerolog.FatalLevel)
 
-			releaseService := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			require.NoError(t, err)
 			gotRelease, gotErr := releaseService.Parse(releaseDir, tt.ignore...)
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, gotErr, tt.expectedErr)
@@ -491,6 +494,71 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/s01e01-group.mkv": {Data: []byte("abcdefghijklmnopqrstuvwxyz0123456789")},
+		"releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/s01e02-group.mkv": {Data: []byte("abcd")},
+		"releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/group.nfo":        {Data: []byte("imdb.com/title/tt0123456\n")},
+		"releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/release.nzb":      {Data: []byte("abc")},
+		"releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/Sample":           {Mode: fs.ModeDir},
+		"releases/Single.1967.German.2160p.BluRay.x264-Group.mkv":                  {Data: []byte("single")},
+	}
+
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	t.Run("directory", func(t *testing.T) {
+		gotRelease, gotErr := releaseService.ParseFS(fsys, "releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group")
+		assert.ErrorIs(t, gotErr, release.ErrForbiddenFiles)
+		require.NotNil(t, gotRelease)
+
+		compareRelease(t, release.Info{
+			Name:          "TVPack.1967.S01.German.1080p.BluRay.x264-Group",
+			Group:         "Group",
+			Size:          36 + 4 + 25 + 3,
+			Extensions:    map[string]int{".mkv": 2, ".nfo": 1, ".nzb": 1},
+			Language:      "german",
+			TagResolution: release.FHD,
+			ProductTitle:  "TVPack",
+			ProductYear:   1967,
+			Section:       release.TVPack,
+			ImdbID:        123456,
+			NFO: &release.NFOFile{
+				Name:    "group.nfo",
+				Content: []byte("imdb.com/title/tt0123456\n"),
+			},
+			Episodes: []release.Episode{
+				{Number: 1, Name: "s01e01-group.mkv"},
+				{Number: 2, Name: "s01e02-group.mkv"},
+			},
+		}, *gotRelease)
+
+		assert.Equal(t, "releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group", gotRelease.BaseDir)
+		assert.Equal(t, "releases/TVPack.1967.S01.German.1080p.BluRay.x264-Group/s01e01-group.mkv",
+			gotRelease.BiggestFile.FullPath)
+		assert.ElementsMatch(t, []string{"release.nzb", "Sample"}, gotRelease.ForbiddenFiles.Names())
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		gotRelease, gotErr := releaseService.ParseFS(fsys, "releases/Single.1967.German.2160p.BluRay.x264-Group.mkv")
+		require.NoError(t, gotErr)
+		assert.True(t, gotRelease.IsSingleFile)
+		assert.Equal(t, "Single.1967.German.2160p.BluRay.x264-Group", gotRelease.Name)
+		assert.Equal(t, int64(6), gotRelease.Size)
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		_, gotErr := releaseService.ParseFS(fsys, "/releases")
+		assert.ErrorIs(t, gotErr, fs.ErrInvalid)
+
+		_, gotErr = releaseService.ParseFS(fsys, "releases/missing")
+		assert.ErrorIs(t, gotErr, fs.ErrNotExist)
+	})
+}
+
 func TestInfo_HasMetaFiles(t *testing.T) {
 	tests := []struct {
 		desc         string
@@ -649,6 +717,22 @@ func TestInfo_HasAnyLanguages(t *testing.T) {
 			inputLanguages: []string{"german", "de"},
 			expected:       true,
 		},
+		{
+			desc: "find german by iso code",
+			inputRelease: release.Info{
+				Languages: release.Languages{Audio: []string{"de"}},
+			},
+			inputLanguages: []string{"ger"},
+			expected:       true,
+		},
+		{
+			desc: "subtitles are no audio language",
+			inputRelease: release.Info{
+				Languages: release.Languages{Subs: []string{"de"}},
+			},
+			inputLanguages: []string{"german"},
+			expected:       false,
+		},
 		{
 			desc:           "no language found",
 			inputRelease:   release.Info{},
@@ -0,0 +1,166 @@
+package release
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/f4n4t/go-release/pkg/utils"
+)
+
+// releaseFS gives access to the files of a release by the FullPath of their tree nodes.
+// The zero value reads directly from the local disk.
+type releaseFS struct {
+	// fsys is the file system the release was parsed from, nil for the local disk.
+	fsys fs.FS
+	// base is the local directory fsys is rooted at, empty if fsys is not backed by the local disk.
+	base string
+}
+
+// isLocal reports whether the FullPath values are real paths on the local disk.
+func (r releaseFS) isLocal() bool {
+	return r.fsys == nil || r.base != ""
+}
+
+// hasContent reports whether the content of the files can be read, which is not the case for a release listing.
+func (r releaseFS) hasContent() bool {
+	_, isListing := r.fsys.(listingFS)
+	return !isListing
+}
+
+// fullPath converts a path inside fsys into the FullPath of a tree node.
+func (r releaseFS) fullPath(fsPath string) string {
+	if r.base == "" {
+		return fsPath
+	}
+	return filepath.Join(r.base, filepath.FromSlash(fsPath))
+}
+
+// fsPath converts the FullPath of a tree node back into a path inside fsys.
+func (r releaseFS) fsPath(fullPath string) (string, error) {
+	if r.base == "" {
+		return fullPath, nil
+	}
+
+	relPath, err := filepath.Rel(r.base, fullPath)
+	if err != nil {
+		return "", fmt.Errorf("get relative path: %w", err)
+	}
+
+	return filepath.ToSlash(relPath), nil
+}
+
+// join joins path elements with the separator matching the FullPath values.
+func (r releaseFS) join(elem ...string) string {
+	if r.isLocal() {
+		return filepath.Join(elem...)
+	}
+	return path.Join(elem...)
+}
+
+// dir returns the directory of a FullPath.
+func (r releaseFS) dir(fullPath string) string {
+	if r.isLocal() {
+		return filepath.Dir(fullPath)
+	}
+	return path.Dir(fullPath)
+}
+
+// open opens the file with the given FullPath.
+func (r releaseFS) open(fullPath string) (fs.File, error) {
+	if r.fsys == nil {
+		return os.Open(fullPath)
+	}
+
+	name, err := r.fsPath(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.fsys.Open(name)
+}
+
+// readFile reads the whole content of the file with the given FullPath.
+func (r releaseFS) readFile(fullPath string) ([]byte, error) {
+	if r.fsys == nil {
+		return os.ReadFile(fullPath)
+	}
+
+	name, err := r.fsPath(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.ReadFile(r.fsys, name)
+}
+
+// stat returns the file info of the file with the given FullPath.
+func (r releaseFS) stat(fullPath string) (fs.FileInfo, error) {
+	if r.fsys == nil {
+		return os.Stat(fullPath)
+	}
+
+	name, err := r.fsPath(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.Stat(r.fsys, name)
+}
+
+// openZip opens the zip file with the given FullPath and returns the reader together with its close function.
+// Files without random access are read into memory.
+func (r releaseFS) openZip(fullPath string) (*zip.Reader, func() error, error) {
+	f, err := r.open(fullPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	if readerAt, ok := f.(io.ReaderAt); ok {
+		zipReader, err := zip.NewReader(readerAt, fileInfo.Size())
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return zipReader, f.Close, nil
+	}
+
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return zipReader, func() error { return nil }, nil
+}
+
+// crcBuilder creates a CRC checker for the file with the given FullPath.
+func (r releaseFS) crcBuilder(fullPath string, wantCRC uint32) (*utils.CheckCRCBuilder, error) {
+	if r.fsys == nil {
+		return utils.NewCheckCRCBuilder(fullPath, wantCRC), nil
+	}
+
+	name, err := r.fsPath(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.NewCheckCRCBuilder(name, wantCRC).WithFS(r.fsys), nil
+}
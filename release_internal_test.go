@@ -129,7 +129,11 @@ func TestCheckIgnoreList(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			service := &Service{}
-			result, err := service.checkIgnoreList(tt.info, tt.path, tt.fileInfo, tt.ignore)
+
+			matcher, err := NewIgnoreMatcher(tt.ignore, true)
+			require.NoError(t, err)
+
+			result, err := service.checkIgnoreList(tt.info, tt.path, tt.fileInfo, matcher)
 
 			if tt.shouldError {
 				assert.Error(t, err)
@@ -163,6 +167,16 @@ func TestService_checkFileExtension(t *testing.T) {
 						Error:    ErrForbiddenExtension,
 					},
 				},
+				Report: &Report{
+					Findings: []Finding{
+						{
+							Code:     CodeForbiddenExtension,
+							Severity: SeverityError,
+							Message:  ErrForbiddenExtension.Error(),
+							Err:      ErrForbiddenExtension,
+						},
+					},
+				},
 			},
 		},
 		{
@@ -243,6 +257,10 @@ func TestService_checkFileExtension(t *testing.T) {
 				tt.expectedInfo.ForbiddenFiles[0].Info = node.Info
 			}
 
+			if tt.expectedInfo.Report != nil {
+				tt.expectedInfo.Report.Findings[0].Path = path
+			}
+
 			if len(tt.expectedInfo.MediaFiles) > 0 {
 				tt.expectedInfo.MediaFiles[0].FullPath = path
 				tt.expectedInfo.MediaFiles[0].Info = node.Info
@@ -258,65 +276,6 @@ func TestService_checkFileExtension(t *testing.T) {
 	}
 }
 
-func TestCanSkip(t *testing.T) {
-	tests := []struct {
-		desc        string
-		path        string
-		pattern     []string
-		ignoreCase  bool
-		expected    bool
-		expectedErr error
-	}{
-		{
-			desc:       "skip sample (ignore case)",
-			path:       "/release-test/Sample",
-			pattern:    []string{"sample"},
-			ignoreCase: true,
-			expected:   true,
-		},
-		{
-			desc:       "skip sample, case sensitive",
-			path:       "/release-test/sample",
-			pattern:    []string{"Sample"},
-			ignoreCase: false,
-			expected:   false,
-		},
-		{
-			desc:       "skip sample (pattern)",
-			path:       "/release-test/Sample",
-			pattern:    []string{"[sS]ample"},
-			ignoreCase: false,
-			expected:   true,
-		},
-		{
-			desc:       "skip test.par2",
-			path:       "/release-test/test.PAR2",
-			pattern:    []string{"test.par2"},
-			ignoreCase: true,
-			expected:   true,
-		},
-		{
-			desc:        "bad pattern",
-			path:        "/release-test/Sample",
-			pattern:     []string{"[sSample"},
-			expectedErr: filepath.ErrBadPattern,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.desc, func(t *testing.T) {
-			got, gotErr := canSkip(tt.path, tt.pattern, tt.ignoreCase)
-			if tt.expectedErr != nil {
-				assert.ErrorIs(t, gotErr, tt.expectedErr)
-				return
-			}
-			require.NoError(t, gotErr)
-
-			assert.Equal(t, tt.expected, got)
-		})
-	}
-}
-
 func TestExtractEpisodesFromFile(t *testing.T) {
 	tests := []struct {
 		desc         string
@@ -342,7 +301,7 @@ func TestExtractEpisodesFromFile(t *testing.T) {
 			desc:      "one episode in main folder",
 			inputFile: createFileNode("/Release.S01E01.German.mkv", false, 4),
 			wantEpisodes: []Episode{
-				createEpisode(1, createFileNode("/Release.S01E01.German.mkv", false, 4)),
+				createEpisode(1, 1, createFileNode("/Release.S01E01.German.mkv", false, 4)),
 			},
 		},
 		{
@@ -351,9 +310,9 @@ func TestExtractEpisodesFromFile(t *testing.T) {
 			wantEpisodes: func() []Episode {
 				file := createFileNode("/Release.S01E01E02E03.German.mkv", false, 4)
 				return []Episode{
-					createEpisode(1, file),
-					createEpisode(2, file),
-					createEpisode(3, file),
+					createEpisode(1, 1, file),
+					createEpisode(1, 2, file),
+					createEpisode(1, 3, file),
 				}
 			}(),
 		},
@@ -368,9 +327,9 @@ func TestExtractEpisodesFromFile(t *testing.T) {
 			wantEpisodes: func() []Episode {
 				file := createFileNode("/Release.S01.German/Release.S01E01E02E03.German/test.mkv", false, 4)
 				return []Episode{
-					createEpisode(1, file),
-					createEpisode(2, file),
-					createEpisode(3, file),
+					createEpisode(1, 1, file),
+					createEpisode(1, 2, file),
+					createEpisode(1, 3, file),
 				}
 			}(),
 		},
@@ -387,7 +346,7 @@ func TestExtractEpisodesFromFile(t *testing.T) {
 			wantEpisodes: func() []Episode {
 				file := createFileNode("/Release.S01E01.German/test.mkv", false, 4)
 				return []Episode{
-					createEpisode(1, file),
+					createEpisode(1, 1, file),
 				}
 			}(),
 		},
@@ -418,8 +377,9 @@ func createFileNode(path string, isDir bool, size int64) *dtree.Node {
 	}
 }
 
-func createEpisode(number int, file *dtree.Node) Episode {
+func createEpisode(season, number int, file *dtree.Node) Episode {
 	return Episode{
+		Season: season,
 		Number: number,
 		Name:   file.Info.Name,
 		File:   file,
@@ -433,10 +393,11 @@ func TestGetEpisodes(t *testing.T) {
 	}
 
 	tests := []struct {
-		desc         string
-		input        input
-		rootNode     *dtree.Node
-		wantEpisodes []Episode
+		desc           string
+		input          input
+		rootNode       *dtree.Node
+		wantEpisodes   []Episode
+		wantDuplicates []Episode
 	}{
 		{
 			desc: "multiple episodes in single file",
@@ -449,9 +410,9 @@ func TestGetEpisodes(t *testing.T) {
 			wantEpisodes: func() []Episode {
 				file1 := createFileNode("/Release.S01.German/s01e01e02e03.mkv", false, 4)
 				return []Episode{
-					createEpisode(1, file1),
-					createEpisode(2, file1),
-					createEpisode(3, file1),
+					createEpisode(1, 1, file1),
+					createEpisode(1, 2, file1),
+					createEpisode(1, 3, file1),
 				}
 			}(),
 		},
@@ -470,9 +431,9 @@ func TestGetEpisodes(t *testing.T) {
 				file2 := createFileNode("/Release.S01.German/s01e02.mkv", false, 3)
 				file3 := createFileNode("/Release.S01.German/s01e03rp.mkv", false, 2)
 				return []Episode{
-					createEpisode(1, file1),
-					createEpisode(2, file2),
-					createEpisode(3, file3),
+					createEpisode(1, 1, file1),
+					createEpisode(1, 2, file2),
+					createEpisode(1, 3, file3),
 				}
 			}(),
 		},
@@ -493,9 +454,9 @@ func TestGetEpisodes(t *testing.T) {
 				file1 := createFileNode("/Release.S01.German/Release.S01E01E02.German/episode.mkv", false, 4)
 				file2 := createFileNode("/Release.S01.German/Release.S01E03.German/episode.mkv", false, 3)
 				return []Episode{
-					createEpisode(1, file1),
-					createEpisode(2, file1),
-					createEpisode(3, file2),
+					createEpisode(1, 1, file1),
+					createEpisode(1, 2, file1),
+					createEpisode(1, 3, file2),
 				}
 			}(),
 		},
@@ -516,12 +477,12 @@ func TestGetEpisodes(t *testing.T) {
 				file3 := createFileNode("/Release.S01.German/s01e03.e04.e05.mkv", false, 2)
 				file4 := createFileNode("/Release.S01.German/s01e06.mkv", false, 1)
 				return []Episode{
-					createEpisode(1, file1),
-					createEpisode(2, file2),
-					createEpisode(3, file3),
-					createEpisode(4, file3),
-					createEpisode(5, file3),
-					createEpisode(6, file4),
+					createEpisode(1, 1, file1),
+					createEpisode(1, 2, file2),
+					createEpisode(1, 3, file3),
+					createEpisode(1, 4, file3),
+					createEpisode(1, 5, file3),
+					createEpisode(1, 6, file4),
 				}
 			}(),
 		},
@@ -542,10 +503,71 @@ func TestGetEpisodes(t *testing.T) {
 				file3 := createFileNode("/Release.German/e003.mkv", false, 4)
 				file4 := createFileNode("/Release.German/e004.mkv", false, 4)
 				return []Episode{
-					createEpisode(1, file1),
-					createEpisode(2, file2),
-					createEpisode(3, file3),
-					createEpisode(4, file4),
+					createEpisode(0, 1, file1),
+					createEpisode(0, 2, file2),
+					createEpisode(0, 3, file3),
+					createEpisode(0, 4, file4),
+				}
+			}(),
+		},
+		{
+			desc: "duplicate episode in another file",
+			input: func() input {
+				parent := createFileNode("/Release.S01.German", true, 0)
+				child1 := createFileNode("/Release.S01.German/s01e01.mkv", false, 4)
+				child2 := createFileNode("/Release.S01.German/s01e02.mkv", false, 3)
+				child3 := createFileNode("/Release.S01.German/s01e02.proper.mkv", false, 2)
+				parent.Children = []*dtree.Node{child1, child2, child3}
+				return input{rootNode: parent, mediaFiles: []*dtree.Node{child1, child2, child3}}
+			}(),
+			wantEpisodes: func() []Episode {
+				file1 := createFileNode("/Release.S01.German/s01e01.mkv", false, 4)
+				file2 := createFileNode("/Release.S01.German/s01e02.mkv", false, 3)
+				return []Episode{
+					createEpisode(1, 1, file1),
+					createEpisode(1, 2, file2),
+				}
+			}(),
+			wantDuplicates: []Episode{
+				createEpisode(1, 2, createFileNode("/Release.S01.German/s01e02.proper.mkv", false, 2)),
+			},
+		},
+		{
+			desc: "episodes without season get the season of the root",
+			input: func() input {
+				parent := createFileNode("/Release.S02.German", true, 0)
+				child1 := createFileNode("/Release.S02.German/e01.mkv", false, 4)
+				child2 := createFileNode("/Release.S02.German/e02.mkv", false, 3)
+				parent.Children = []*dtree.Node{child1, child2}
+				return input{rootNode: parent, mediaFiles: []*dtree.Node{child1, child2}}
+			}(),
+			wantEpisodes: func() []Episode {
+				file1 := createFileNode("/Release.S02.German/e01.mkv", false, 4)
+				file2 := createFileNode("/Release.S02.German/e02.mkv", false, 3)
+				return []Episode{
+					createEpisode(2, 1, file1),
+					createEpisode(2, 2, file2),
+				}
+			}(),
+		},
+		{
+			desc: "multiple seasons",
+			input: func() input {
+				parent := createFileNode("/Release.S01-S02.German", true, 0)
+				child1 := createFileNode("/Release.S01-S02.German/s02e01.mkv", false, 4)
+				child2 := createFileNode("/Release.S01-S02.German/s01e02.mkv", false, 3)
+				child3 := createFileNode("/Release.S01-S02.German/s01e01.mkv", false, 2)
+				parent.Children = []*dtree.Node{child1, child2, child3}
+				return input{rootNode: parent, mediaFiles: []*dtree.Node{child1, child2, child3}}
+			}(),
+			wantEpisodes: func() []Episode {
+				file1 := createFileNode("/Release.S01-S02.German/s02e01.mkv", false, 4)
+				file2 := createFileNode("/Release.S01-S02.German/s01e02.mkv", false, 3)
+				file3 := createFileNode("/Release.S01-S02.German/s01e01.mkv", false, 2)
+				return []Episode{
+					createEpisode(1, 1, file3),
+					createEpisode(1, 2, file2),
+					createEpisode(2, 1, file1),
 				}
 			}(),
 		},
@@ -553,8 +575,9 @@ func TestGetEpisodes(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			got := getEpisodes(tt.input.mediaFiles, tt.input.rootNode)
+			got, gotDuplicates := getEpisodes(tt.input.mediaFiles, tt.input.rootNode)
 			assert.Equal(t, tt.wantEpisodes, got)
+			assert.Equal(t, tt.wantDuplicates, gotDuplicates)
 		})
 	}
 }
@@ -623,21 +646,25 @@ func TestDetectSectionByExtensions(t *testing.T) {
 }
 
 func TestRemoveDuplicateEpisodes(t *testing.T) {
+	first, second := &dtree.Node{}, &dtree.Node{}
+
 	input := []Episode{
-		{Number: 1},
-		{Number: 1},
-		{Number: 3},
-		{Number: 4},
-		{Number: 2},
-		{Number: 4},
+		{Number: 1, File: first},
+		{Number: 1, File: first},
+		{Number: 3, File: first},
+		{Number: 4, File: first},
+		{Number: 2, File: second},
+		{Number: 4, File: second},
 	}
 
 	expected := []Episode{
-		{Number: 1},
-		{Number: 3},
-		{Number: 4},
-		{Number: 2},
+		{Number: 1, File: first},
+		{Number: 3, File: first},
+		{Number: 4, File: first},
+		{Number: 2, File: second},
 	}
 
-	assert.Equal(t, expected, removeDuplicateEpisodes(input))
+	episodes, duplicates := removeDuplicateEpisodes(input)
+	assert.Equal(t, expected, episodes)
+	assert.Equal(t, []Episode{{Number: 4, File: second}}, duplicates)
 }
@@ -0,0 +1,220 @@
+package release
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ListingEntry is a single entry of a release listing, e.g. from an FTP LIST, a site dupe or the srrdb file list.
+type ListingEntry struct {
+	// Path is the path relative to the release directory, e.g. "Sample/group-sample.mkv".
+	// A trailing slash marks an (empty) directory, backslashes are treated as separators.
+	Path string `json:"path"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+	// ModTime is the modification time of the file, the zero value if unknown.
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ParseListing works like Parse, but only uses the given listing of the release, no file is ever accessed.
+// Section detection, episodes, forbidden files, archive counting and the biggest file work the same as in Parse,
+// the NFO is not read and no mediainfo is generated. The FullPath of every node is the slash separated path
+// below the release name, e.g. "Release.Name-Group/group.nfo".
+// A single file release is parsed if the listing only contains one file with the release name as path.
+func (s *Service) ParseListing(name string, entries []ListingEntry, ignore ...string) (*Info, error) {
+	fsys, err := newListingFS(name, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parse(releaseFS{fsys: fsys}, name, ignore)
+}
+
+// listingFS is a file system that only holds the metadata of a release listing.
+// Directories can be read, but opening a file returns ErrNoContent.
+type listingFS map[string]*listingNode
+
+// newListingFS creates the file system for the listing of the release with the given name.
+func newListingFS(name string, entries []ListingEntry) (listingFS, error) {
+	if name == "." || strings.Contains(name, "/") || !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "parse listing", Path: name, Err: fs.ErrInvalid}
+	}
+
+	fsys := listingFS{}
+
+	if len(entries) == 1 && path.Clean(strings.ReplaceAll(entries[0].Path, `\`, "/")) == name {
+		fsys[name] = &listingNode{name: name, size: entries[0].Size, modTime: entries[0].ModTime}
+		return fsys, nil
+	}
+
+	fsys[name] = &listingNode{name: name, dir: true}
+
+	for _, entry := range entries {
+		entryPath := strings.Trim(strings.ReplaceAll(entry.Path, `\`, "/"), "/")
+		isDir := strings.HasSuffix(entry.Path, "/") || strings.HasSuffix(entry.Path, `\`)
+
+		if entryPath == "" || !fs.ValidPath(entryPath) {
+			return nil, &fs.PathError{Op: "parse listing", Path: entry.Path, Err: fs.ErrInvalid}
+		}
+
+		if err := fsys.add(path.Join(name, entryPath), entry, isDir); err != nil {
+			return nil, err
+		}
+	}
+
+	return fsys, nil
+}
+
+// add adds a file or directory together with all missing parent directories.
+func (l listingFS) add(name string, entry ListingEntry, isDir bool) error {
+	if existing, ok := l[name]; ok {
+		if existing.dir != isDir {
+			return &fs.PathError{Op: "parse listing", Path: entry.Path, Err: fs.ErrExist}
+		}
+		if !isDir {
+			existing.size, existing.modTime = entry.Size, entry.ModTime
+		}
+		return nil
+	}
+
+	node := &listingNode{name: path.Base(name), dir: isDir}
+	if !isDir {
+		node.size, node.modTime = entry.Size, entry.ModTime
+	}
+
+	l[name] = node
+
+	parentName := path.Dir(name)
+	if err := l.add(parentName, ListingEntry{Path: parentName}, true); err != nil {
+		return err
+	}
+
+	parent := l[parentName]
+	parent.children = append(parent.children, node)
+
+	return nil
+}
+
+// Open implements fs.FS, only directories can be opened.
+func (l listingFS) Open(name string) (fs.File, error) {
+	node, err := l.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !node.dir {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ErrNoContent}
+	}
+
+	return &listingDir{node: node}, nil
+}
+
+// Stat implements fs.StatFS.
+func (l listingFS) Stat(name string) (fs.FileInfo, error) {
+	return l.lookup("stat", name)
+}
+
+// ReadDir implements fs.ReadDirFS, the entries are sorted by name.
+func (l listingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := l.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !node.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	return node.entries(), nil
+}
+
+// lookup returns the node with the given name.
+func (l listingFS) lookup(op, name string) (*listingNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	node, ok := l[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	return node, nil
+}
+
+// listingNode is a file or directory of a listing, it implements fs.FileInfo and fs.DirEntry.
+type listingNode struct {
+	name     string
+	size     int64
+	modTime  time.Time
+	dir      bool
+	children []*listingNode
+}
+
+// entries returns the children as directory entries sorted by name.
+func (n *listingNode) entries() []fs.DirEntry {
+	children := slices.Clone(n.children)
+	slices.SortFunc(children, func(a, b *listingNode) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	entries := make([]fs.DirEntry, len(children))
+	for i, child := range children {
+		entries[i] = child
+	}
+
+	return entries
+}
+
+func (n *listingNode) Name() string               { return n.name }
+func (n *listingNode) Size() int64                { return n.size }
+func (n *listingNode) ModTime() time.Time         { return n.modTime }
+func (n *listingNode) IsDir() bool                { return n.dir }
+func (n *listingNode) Sys() any                   { return nil }
+func (n *listingNode) Type() fs.FileMode          { return n.Mode().Type() }
+func (n *listingNode) Info() (fs.FileInfo, error) { return n, nil }
+
+func (n *listingNode) Mode() fs.FileMode {
+	if n.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// listingDir is an opened directory of a listingFS.
+type listingDir struct {
+	node    *listingNode
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *listingDir) Stat() (fs.FileInfo, error) { return d.node, nil }
+func (d *listingDir) Close() error               { return nil }
+
+func (d *listingDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.node.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *listingDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		d.entries = d.node.entries()
+	}
+
+	remaining := d.entries[d.offset:]
+	if count > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if count > 0 && count < len(remaining) {
+		remaining = remaining[:count]
+	}
+
+	d.offset += len(remaining)
+
+	return remaining, nil
+}
@@ -0,0 +1,113 @@
+package release_test
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/f4n4t/go-dtree"
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListing(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	t.Run("tv pack", func(t *testing.T) {
+		name := "TVPack.1967.S01.German.1080p.BluRay.x264-Group"
+		modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		gotRelease, gotErr := releaseService.ParseListing(name, []release.ListingEntry{
+			{Path: "group.nfo", Size: 4096},
+			{Path: "s01e02-group.mkv", Size: 2000},
+			{Path: "s01e01-group.mkv", Size: 3000, ModTime: modTime},
+			{Path: `Sample\s01e01-sample-group.mkv`, Size: 100},
+			{Path: "Proof/", Size: 0},
+			{Path: "release.nzb", Size: 10},
+		})
+		assert.ErrorIs(t, gotErr, release.ErrForbiddenFiles)
+		require.NotNil(t, gotRelease)
+
+		compareRelease(t, release.Info{
+			Name:          name,
+			Group:         "Group",
+			Size:          4096 + 2000 + 3000 + 100 + 10,
+			Extensions:    map[string]int{".mkv": 3, ".nfo": 1, ".nzb": 1},
+			Language:      "german",
+			TagResolution: release.FHD,
+			ProductTitle:  "TVPack",
+			ProductYear:   1967,
+			Section:       release.TVPack,
+			BiggestFile:   &dtree.Node{Info: &dtree.FileInfo{Name: "group.nfo", Size: 4096}},
+			Episodes: []release.Episode{
+				{Number: 1, Name: "s01e01-group.mkv"},
+				{Number: 2, Name: "s01e02-group.mkv"},
+			},
+		}, *gotRelease)
+
+		// the nfo content is not available in a listing
+		assert.Nil(t, gotRelease.NFO)
+		assert.Nil(t, gotRelease.MediaInfo)
+		assert.Equal(t, name, gotRelease.BaseDir)
+		assert.ElementsMatch(t, []string{"release.nzb", "Proof"}, gotRelease.ForbiddenFiles.Names())
+
+		episode, err := gotRelease.Root.GetFile("s01e01-group.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, name+"/s01e01-group.mkv", episode.FullPath)
+		assert.Equal(t, modTime, episode.Info.ModTime)
+	})
+
+	t.Run("archives", func(t *testing.T) {
+		name := "Movie.2020.German.DL.720p.BluRay.x264-Group"
+
+		gotRelease, gotErr := releaseService.ParseListing(name, []release.ListingEntry{
+			{Path: "group.nfo", Size: 100},
+			{Path: "group.sfv", Size: 50},
+			{Path: "group.rar", Size: 1000},
+			{Path: "group.r00", Size: 1000},
+			{Path: "group.r01", Size: 500},
+		})
+		require.NoError(t, gotErr)
+
+		assert.Equal(t, 3, gotRelease.ArchiveCount)
+		assert.Equal(t, 1, gotRelease.SfvCount)
+		assert.Equal(t, release.Movies, gotRelease.Section)
+
+		// checks that need the file content fail
+		assert.ErrorIs(t, releaseService.CheckSFV(gotRelease, false), release.ErrNoContent)
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		name := "Single.1967.German.2160p.BluRay.x264-Group.mkv"
+
+		gotRelease, gotErr := releaseService.ParseListing(name, []release.ListingEntry{{Path: name, Size: 6}})
+		require.NoError(t, gotErr)
+
+		assert.True(t, gotRelease.IsSingleFile)
+		assert.Equal(t, "Single.1967.German.2160p.BluRay.x264-Group", gotRelease.Name)
+		assert.Equal(t, int64(6), gotRelease.Size)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, gotErr := releaseService.ParseListing("Release-Group", nil)
+		assert.ErrorIs(t, gotErr, release.ErrEmptyFolder)
+
+		_, gotErr = releaseService.ParseListing("Sub/Release-Group", []release.ListingEntry{{Path: "a.mkv", Size: 1}})
+		assert.ErrorIs(t, gotErr, fs.ErrInvalid)
+
+		_, gotErr = releaseService.ParseListing("Release-Group", []release.ListingEntry{{Path: "../a.mkv", Size: 1}})
+		assert.ErrorIs(t, gotErr, fs.ErrInvalid)
+
+		_, gotErr = releaseService.ParseListing("Release-Group", []release.ListingEntry{
+			{Path: "Sample", Size: 1},
+			{Path: "Sample/a.mkv", Size: 1},
+		})
+		assert.ErrorIs(t, gotErr, fs.ErrExist)
+	})
+}
@@ -0,0 +1,258 @@
+package release
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Token is a single matched part of a release name together with its position in the name.
+type Token struct {
+	// Value is the matched text exactly as it appears in the release name (the title is the only cleaned value).
+	Value string `json:"value"`
+	// Start is the byte offset of the first character of the match.
+	Start int `json:"start"`
+	// End is the byte offset directly after the last character of the match.
+	End int `json:"end"`
+}
+
+// Found reports whether the token was matched in the release name.
+func (t Token) Found() bool {
+	return t.End > t.Start
+}
+
+// Int returns the value of the token as an integer, or 0 if it is not numeric.
+func (t Token) Int() int {
+	i, _ := strconv.Atoi(t.Value)
+	return i
+}
+
+// normalized returns the lowercase value without any separators, e.g. "READ.NFO" becomes "readnfo".
+func (t Token) normalized() string {
+	return strings.ToLower(tokenSeparators.Replace(t.Value))
+}
+
+// ReleaseName holds all tokens that could be parsed from a release name.
+type ReleaseName struct {
+	// Title is the product title without any meta-tags, separators are replaced with spaces.
+	Title Token `json:"title"`
+	// Year is the production year (the second year if there is more than one, e.g. "Blade.Runner.2049.2017").
+	Year Token `json:"year"`
+	// Season is the season number, e.g. "01" for S01E03.
+	Season Token `json:"season"`
+	// Episode is the episode number, e.g. "03" for S01E03.
+	Episode Token `json:"episode"`
+	// Resolution is the resolution tag, e.g. "1080p".
+	Resolution Token `json:"resolution"`
+	// Source is the source tag, e.g. "WEB", "BluRay" or "HDTV".
+	Source Token `json:"source"`
+	// VideoCodec is the video codec tag, e.g. "x264" or "HEVC".
+	VideoCodec Token `json:"video_codec"`
+	// AudioCodec is the audio codec tag, e.g. "DTS-HD.MA" or "AC3".
+	AudioCodec Token `json:"audio_codec"`
+	// AudioChannels is the channel layout following the audio codec, e.g. "5.1".
+	AudioChannels Token `json:"audio_channels"`
+	// HDR is the HDR tag, e.g. "HDR" or "HDR10Plus".
+	HDR Token `json:"hdr"`
+	// DolbyVision is the Dolby Vision tag, e.g. "DV".
+	DolbyVision Token `json:"dolby_vision"`
+	// Editions holds all edition tags, e.g. "EXTENDED", "DC" or "UNCUT".
+	Editions []Token `json:"editions"`
+	// Flags holds all release flags, e.g. "PROPER", "REPACK", "iNTERNAL", "DIRFIX" or "READ.NFO".
+	Flags []Token `json:"flags"`
+	// Language is the first language tag found, e.g. "German".
+	Language Token `json:"language"`
+	// Group is the release group (final part of the release after the -).
+	Group Token `json:"group"`
+}
+
+// HasFlag checks if the release name contains the given flag, separators and case are ignored.
+func (rn ReleaseName) HasFlag(flag string) bool {
+	return containsToken(rn.Flags, flag)
+}
+
+// HasEdition checks if the release name contains the given edition, separators and case are ignored.
+func (rn ReleaseName) HasEdition(edition string) bool {
+	return containsToken(rn.Editions, edition)
+}
+
+// containsToken checks if any of the tokens equals the given value, separators and case are ignored.
+func containsToken(tokens []Token, value string) bool {
+	value = Token{Value: value}.normalized()
+	for _, t := range tokens {
+		if t.normalized() == value {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenSeparators removes the typical separators from a token value.
+var tokenSeparators = strings.NewReplacer(".", "", "_", "", "-", "", " ", "")
+
+// tokenPattern wraps the given alternatives with separator boundaries, the token itself is the first capture group.
+func tokenPattern(alternatives string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(?:^|[._ -])(` + alternatives + `)(?:[._ -]|$)`)
+}
+
+// nameRegexes holds the patterns for the tokens of ParseName.
+var nameRegexes = struct {
+	seasonEpisode, altEpisode, episode, resolution, source, videoCodec, audioCodec, audioChannels, hdr, dolbyVision,
+	edition, flag, language *regexp.Regexp
+}{
+	seasonEpisode: tokenPattern(`s(\d{1,4})(?:[._-]?e(\d{1,4}))?`),
+	altEpisode:    tokenPattern(`(\d{1,2})x(\d{2,3})`),
+	episode:       tokenPattern(`e(?:p(?:isode)?)?[._]?(\d{1,4})`),
+	resolution:    regexp.MustCompile(`(?i)(?:^|[._ (\[-])((?:480|576|720|1080|2160|4320)[pi])(?:[._ )\]-]|$)`),
+	source: tokenPattern(`uhd[._-]?blu-?ray|m?blu-?ray|bdrip|brrip|bd(?:25|50|66|100)?|web[._-]?dl|web-?rip|web|` +
+		`hdtv|pdtv|sdtv|dsr|dvd-?rip|dvd[59r]?|hd-?dvd|hdrip|vhs(?:rip)?|tvrip|satrip|dvb[sct]?|hdcam|cam|telesync`),
+	videoCodec:    tokenPattern(`[xh][._]?26[456]|avc|hevc|av1|vp9|xvid|divx|mpeg-?2|vc-?1`),
+	audioCodec:    regexp.MustCompile(`(?i)(?:^|[._ -])(dts-?hd(?:[._-]?ma)?|dts-?x|dts|truehd|e-?ac-?3|ac-?3d?|ddp|dd\+|dd|aac|flac|lpcm|opus|mp3)(?:[._ -]|$|[1-9][._][0-2])`),
+	audioChannels: regexp.MustCompile(`(?i)^[._-]?(?:atmos[._-]?)?([1-9][._][0-2])(?:[._ -]|$)`),
+	hdr:           tokenPattern(`hdr10(?:\+|plus)?|hdr|hlg`),
+	dolbyVision:   tokenPattern(`dv|dovi|dolby[._-]?vision`),
+	edition: tokenPattern(`extended(?:[._-](?:cut|edition))?|directors?[._-]?cut|dc|uncut|unrated|remastered|` +
+		`theatrical(?:[._-]cut)?|imax|criterion|special[._-]edition|final[._-]cut`),
+	flag: tokenPattern(`proper|repack|rerip|real|internal|limited|dirfix|nfofix|samplefix|prooffix|subfix|syncfix|` +
+		`read[._-]?nfo`),
+	language: tokenPattern(strings.Join(languages, "|")),
+}
+
+// ParseName parses all known tokens from a release name.
+// Every token records its position in the name, so callers can highlight or strip them.
+func ParseName(name string) ReleaseName {
+	var rn ReleaseName
+
+	if m := Regexes.Group.FindStringSubmatchIndex(name); m != nil {
+		rn.Group = tokenFromIndex(name, m[2], m[3])
+	}
+
+	if m := Regexes.Year.FindAllStringSubmatchIndex(name, -1); m != nil {
+		idx := 0
+		if len(m) > 1 {
+			idx = 1
+		}
+		rn.Year = tokenFromIndex(name, m[idx][2], m[idx][3])
+	}
+
+	if m := nameRegexes.seasonEpisode.FindStringSubmatchIndex(name); m != nil {
+		rn.Season = tokenFromIndex(name, m[4], m[5])
+		rn.Episode = tokenFromIndex(name, m[6], m[7])
+	} else if m := nameRegexes.altEpisode.FindStringSubmatchIndex(name); m != nil {
+		rn.Season = tokenFromIndex(name, m[4], m[5])
+		rn.Episode = tokenFromIndex(name, m[6], m[7])
+	} else if m := nameRegexes.episode.FindStringSubmatchIndex(name); m != nil {
+		rn.Episode = tokenFromIndex(name, m[4], m[5])
+	}
+
+	rn.Resolution = firstToken(nameRegexes.resolution, name)
+	rn.Source = firstToken(nameRegexes.source, name)
+	rn.VideoCodec = firstToken(nameRegexes.videoCodec, name)
+	rn.HDR = firstToken(nameRegexes.hdr, name)
+	rn.DolbyVision = firstToken(nameRegexes.dolbyVision, name)
+	rn.Editions = findTokens(nameRegexes.edition, name)
+	rn.Flags = findTokens(nameRegexes.flag, name)
+	rn.Language = firstToken(nameRegexes.language, name)
+
+	if rn.AudioCodec = firstToken(nameRegexes.audioCodec, name); rn.AudioCodec.Found() {
+		if m := nameRegexes.audioChannels.FindStringSubmatchIndex(name[rn.AudioCodec.End:]); m != nil {
+			rn.AudioChannels = tokenFromIndex(name, rn.AudioCodec.End+m[2], rn.AudioCodec.End+m[3])
+		}
+	}
+
+	rn.Title = parseTitleToken(name, rn)
+
+	// fansub names have the group in front and the tags in brackets, e.g. [Group] Title - 12 [1080p]
+	if an, ok := ParseAnimeName(name); ok {
+		rn.Group, rn.Title = an.Group, an.Title
+		if !rn.Resolution.Found() {
+			rn.Resolution = an.Resolution
+		}
+	}
+
+	return rn
+}
+
+// parseTitleToken returns the title as everything in front of the first meta token.
+func parseTitleToken(name string, rn ReleaseName) Token {
+	end := len(name)
+	if rn.Group.Found() {
+		// exclude the separator in front of the group
+		end = rn.Group.Start - 1
+	}
+
+	meta := []Token{rn.Year, rn.Season, rn.Episode, rn.Resolution, rn.Source, rn.VideoCodec, rn.AudioCodec, rn.HDR,
+		rn.DolbyVision, rn.Language}
+	meta = append(meta, rn.Editions...)
+	meta = append(meta, rn.Flags...)
+
+	for _, t := range meta {
+		if t.Found() && t.Start > 0 && t.Start < end {
+			end = t.Start
+		}
+	}
+
+	// season and episode tokens start after the prefix letter
+	if end > 0 && (end == rn.Season.Start || end == rn.Episode.Start) {
+		for end > 0 && !isTokenSeparator(name[end-1]) {
+			end--
+		}
+	}
+
+	for end > 0 && isTokenSeparator(name[end-1]) {
+		end--
+	}
+
+	if end <= 0 {
+		return Token{}
+	}
+
+	return Token{
+		Value: strings.Join(strings.FieldsFunc(name[:end], func(r rune) bool {
+			return r < 128 && isTokenSeparator(byte(r))
+		}), " "),
+		Start: 0,
+		End:   end,
+	}
+}
+
+// isTokenSeparator checks for the typical separators used in release names.
+func isTokenSeparator(c byte) bool {
+	return c == '.' || c == '_' || c == '-' || c == ' '
+}
+
+// tokenFromIndex creates a token from a submatch index, negative indices (no match) return an empty token.
+func tokenFromIndex(name string, start, end int) Token {
+	if start < 0 || end < 0 {
+		return Token{}
+	}
+	return Token{Value: name[start:end], Start: start, End: end}
+}
+
+// firstToken returns the first token matched by the regex or an empty token.
+func firstToken(re *regexp.Regexp, name string) Token {
+	if m := re.FindStringSubmatchIndex(name); m != nil {
+		return tokenFromIndex(name, m[2], m[3])
+	}
+	return Token{}
+}
+
+// findTokens returns all tokens matched by the regex.
+// The search restarts directly after each token, so adjacent tokens can share a separator.
+func findTokens(re *regexp.Regexp, name string) []Token {
+	var (
+		tokens []Token
+		offset int
+	)
+
+	for offset < len(name) {
+		m := re.FindStringSubmatchIndex(name[offset:])
+		if m == nil {
+			break
+		}
+		tokens = append(tokens, tokenFromIndex(name, offset+m[2], offset+m[3]))
+		offset += m[3]
+	}
+
+	return tokens
+}
@@ -0,0 +1,119 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected release.ReleaseName
+	}{
+		{
+			name:  "movie with all tags",
+			input: "The.Matrix.1999.EXTENDED.PROPER.READ.NFO.German.DL.DTS-HD.MA.5.1.1080p.BluRay.HDR.DV.x264-GROUP",
+			expected: release.ReleaseName{
+				Title:         release.Token{Value: "The Matrix", Start: 0, End: 10},
+				Year:          release.Token{Value: "1999", Start: 11, End: 15},
+				Editions:      []release.Token{{Value: "EXTENDED", Start: 16, End: 24}},
+				Flags:         []release.Token{{Value: "PROPER", Start: 25, End: 31}, {Value: "READ.NFO", Start: 32, End: 40}},
+				Language:      release.Token{Value: "German", Start: 41, End: 47},
+				AudioCodec:    release.Token{Value: "DTS-HD.MA", Start: 51, End: 60},
+				AudioChannels: release.Token{Value: "5.1", Start: 61, End: 64},
+				Resolution:    release.Token{Value: "1080p", Start: 65, End: 70},
+				Source:        release.Token{Value: "BluRay", Start: 71, End: 77},
+				HDR:           release.Token{Value: "HDR", Start: 78, End: 81},
+				DolbyVision:   release.Token{Value: "DV", Start: 82, End: 84},
+				VideoCodec:    release.Token{Value: "x264", Start: 85, End: 89},
+				Group:         release.Token{Value: "GROUP", Start: 90, End: 95},
+			},
+		},
+		{
+			name:  "tv episode",
+			input: "The.Last.of.Us.S01E03.1080p.WEB.H264-CAKES",
+			expected: release.ReleaseName{
+				Title:      release.Token{Value: "The Last of Us", Start: 0, End: 14},
+				Season:     release.Token{Value: "01", Start: 16, End: 18},
+				Episode:    release.Token{Value: "03", Start: 19, End: 21},
+				Resolution: release.Token{Value: "1080p", Start: 22, End: 27},
+				Source:     release.Token{Value: "WEB", Start: 28, End: 31},
+				VideoCodec: release.Token{Value: "H264", Start: 32, End: 36},
+				Group:      release.Token{Value: "CAKES", Start: 37, End: 42},
+			},
+		},
+		{
+			name:  "alternative episode format",
+			input: "Succession.1x09.1080p.WEB.H264-GLHF",
+			expected: release.ReleaseName{
+				Title:      release.Token{Value: "Succession", Start: 0, End: 10},
+				Season:     release.Token{Value: "1", Start: 11, End: 12},
+				Episode:    release.Token{Value: "09", Start: 13, End: 15},
+				Resolution: release.Token{Value: "1080p", Start: 16, End: 21},
+				Source:     release.Token{Value: "WEB", Start: 22, End: 25},
+				VideoCodec: release.Token{Value: "H264", Start: 26, End: 30},
+				Group:      release.Token{Value: "GLHF", Start: 31, End: 35},
+			},
+		},
+		{
+			name:  "uhd bluray with glued channels",
+			input: "Godzilla.vs.Kong.2021.UHD.BluRay.2160p.DTS-HD.MA5.1.HEVC.REMUX-FraMeSToR",
+			expected: release.ReleaseName{
+				Title:         release.Token{Value: "Godzilla vs Kong", Start: 0, End: 16},
+				Year:          release.Token{Value: "2021", Start: 17, End: 21},
+				Source:        release.Token{Value: "UHD.BluRay", Start: 22, End: 32},
+				Resolution:    release.Token{Value: "2160p", Start: 33, End: 38},
+				AudioCodec:    release.Token{Value: "DTS-HD.MA", Start: 39, End: 48},
+				AudioChannels: release.Token{Value: "5.1", Start: 48, End: 51},
+				VideoCodec:    release.Token{Value: "HEVC", Start: 52, End: 56},
+				Group:         release.Token{Value: "FraMeSToR", Start: 63, End: 72},
+			},
+		},
+		{
+			name:  "underscores",
+			input: "Mad_Max_Fury_Road_2015_1080p_BluRay_x264_DTS-JYK",
+			expected: release.ReleaseName{
+				Title:      release.Token{Value: "Mad Max Fury Road", Start: 0, End: 17},
+				Year:       release.Token{Value: "2015", Start: 18, End: 22},
+				Resolution: release.Token{Value: "1080p", Start: 23, End: 28},
+				Source:     release.Token{Value: "BluRay", Start: 29, End: 35},
+				VideoCodec: release.Token{Value: "x264", Start: 36, End: 40},
+				AudioCodec: release.Token{Value: "DTS", Start: 41, End: 44},
+				Group:      release.Token{Value: "JYK", Start: 45, End: 48},
+			},
+		},
+		{
+			name:     "no tokens",
+			input:    "MyHomeVideo",
+			expected: release.ReleaseName{Title: release.Token{Value: "MyHomeVideo", Start: 0, End: 11}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := release.ParseName(tt.input)
+			assert.Equal(t, tt.expected, got, "Release: %s", tt.input)
+
+			// every token has to point to its value in the name
+			for _, token := range append(got.Flags, got.Group, got.Year, got.Source, got.AudioCodec) {
+				if token.Found() {
+					assert.Equal(t, token.Value, tt.input[token.Start:token.End])
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseName_HasFlag(t *testing.T) {
+	rn := release.ParseName("Movie.2020.DC.iNTERNAL.READ_NFO.1080p.WEB.x264-GROUP")
+
+	assert.True(t, rn.HasFlag("internal"))
+	assert.True(t, rn.HasFlag("READ.NFO"))
+	assert.False(t, rn.HasFlag("PROPER"))
+	assert.True(t, rn.HasEdition("dc"))
+	assert.False(t, rn.HasEdition("UNCUT"))
+	assert.Equal(t, 2020, rn.Year.Int())
+}
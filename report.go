@@ -0,0 +1,201 @@
+package release
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FindingCode identifies the kind of finding in a report.
+// Findings of custom rules (see Ruleset) use the ID of the rule as code.
+type FindingCode string
+
+const (
+	// CodeForbiddenCharacters is a file or folder name with forbidden characters.
+	CodeForbiddenCharacters FindingCode = RuleForbiddenCharacters
+	// CodeForbiddenCharactersAnime is a file or folder name of an anime release with forbidden characters.
+	CodeForbiddenCharactersAnime FindingCode = RuleForbiddenCharactersAnime
+	// CodeEmptyFile is an empty file.
+	CodeEmptyFile FindingCode = RuleEmptyFile
+	// CodeForbiddenExtension is a file with a forbidden extension.
+	CodeForbiddenExtension FindingCode = RuleForbiddenExtension
+	// CodeEmptyFolder is an empty subfolder.
+	CodeEmptyFolder FindingCode = "empty-folder"
+	// CodeSymlink is a symlink rejected by SymlinkReject.
+	CodeSymlink FindingCode = "symlink"
+	// CodeBrokenSymlink is a symlink whose target does not exist.
+	CodeBrokenSymlink FindingCode = "broken-symlink"
+	// CodeSymlinkLoop is a followed symlink that links to one of its parent folders.
+	CodeSymlinkLoop FindingCode = "symlink-loop"
+	// CodeSpecialFile is a socket, named pipe or device file.
+	CodeSpecialFile FindingCode = "special-file"
+	// CodeHardlink is a file that is a hardlink of another file in the release.
+	CodeHardlink FindingCode = "hardlink"
+	// CodeDuplicateContent is a file with the same content as another file in the release.
+	CodeDuplicateContent FindingCode = "duplicate-content"
+	// CodeMissingEpisode is an episode or a whole season that is missing in a TVPack.
+	CodeMissingEpisode FindingCode = "missing-episode"
+	// CodeDuplicateEpisode is a file with an episode that was already found in another file.
+	CodeDuplicateEpisode FindingCode = "duplicate-episode"
+	// CodeMissingDisc is a disc number that is missing between the discs of a release.
+	CodeMissingDisc FindingCode = "missing-disc"
+	// CodeEmptyDisc is a disc without archives and media files.
+	CodeEmptyDisc FindingCode = "empty-disc"
+	// CodeMissingSfv is a disc with archives but without a sfv file.
+	CodeMissingSfv FindingCode = "missing-sfv"
+	// CodeUnlistedFile is an archive that is not listed in the sfv of its disc.
+	CodeUnlistedFile FindingCode = "unlisted-file"
+	// CodeDiscMismatch is a disc whose content or volume size differs from the first disc.
+	CodeDiscMismatch FindingCode = "disc-mismatch"
+	// CodeFixContent is a file that is not allowed in the type of fix.
+	CodeFixContent FindingCode = RuleFixContent
+	// CodeFixNFO is a DIRFIX or NFOFIX without an NFO.
+	CodeFixNFO FindingCode = RuleFixNFO
+	// CodeMissingFile is a file listed in a sfv or srr that does not exist in the release.
+	CodeMissingFile FindingCode = "missing-file"
+	// CodeInvalidSfv is a sfv file that is empty or can't be parsed.
+	CodeInvalidSfv FindingCode = "invalid-sfv"
+	// CodeSfvMismatch is a file whose CRC does not match the sfv.
+	CodeSfvMismatch FindingCode = "sfv-mismatch"
+	// CodeCRCMismatch is a file whose CRC does not match the CRC embedded in its name.
+	CodeCRCMismatch FindingCode = "crc-mismatch"
+	// CodeInvalidZip is a zip file without a file count in its .diz or without an archive.
+	CodeInvalidZip FindingCode = "invalid-zip"
+	// CodeZipCountMismatch is a folder whose count of zip files does not match the count in the .diz.
+	CodeZipCountMismatch FindingCode = "zip-count-mismatch"
+	// CodeZipSizeMismatch is a folder whose zip files contain archives of more than two sizes.
+	CodeZipSizeMismatch FindingCode = "zip-size-mismatch"
+	// CodeNoSrr is a release name without a record on srrdb.
+	CodeNoSrr FindingCode = "no-srr"
+	// CodeInvalidSrr is a srrdb record that can't be used for the check.
+	CodeInvalidSrr FindingCode = "invalid-srr"
+	// CodeSrrMismatch is a file whose size or CRC does not match the srr.
+	CodeSrrMismatch FindingCode = "srr-mismatch"
+)
+
+// Finding is a single problem found while parsing or checking a release.
+type Finding struct {
+	// Code identifies the kind of the finding.
+	Code FindingCode `json:"code"`
+	// Severity is the severity of the finding, only SeverityError rejects the release.
+	Severity Severity `json:"severity"`
+	// Path is the FullPath of the affected file or folder, empty if the finding is about the whole release.
+	Path string `json:"path,omitempty"`
+	// Message is a human-readable description.
+	Message string `json:"message"`
+	// Err is the underlying error, e.g. ErrEmptyFile or ErrSfvValidationFailed. It is not encoded to JSON.
+	Err error `json:"-"`
+}
+
+// Error implements the error interface.
+func (f Finding) Error() string {
+	if f.Path != "" {
+		return fmt.Sprintf("%s: %s (%s)", f.Code, f.Message, f.Path)
+	}
+	return fmt.Sprintf("%s: %s", f.Code, f.Message)
+}
+
+// Unwrap returns the underlying error.
+func (f Finding) Unwrap() error {
+	return f.Err
+}
+
+// Report collects all findings of a parse or check in the order they were found.
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// add adds a finding with the message of the error.
+func (r *Report) add(code FindingCode, severity Severity, path string, err error) {
+	r.Findings = append(r.Findings, Finding{
+		Code:     code,
+		Severity: severity,
+		Path:     path,
+		Message:  err.Error(),
+		Err:      err,
+	})
+}
+
+// merge adds all findings of the other report.
+func (r *Report) merge(other *Report) {
+	if other != nil {
+		r.Findings = append(r.Findings, other.Findings...)
+	}
+}
+
+// HasErrors reports whether any finding has SeverityError.
+func (r *Report) HasErrors() bool {
+	return r != nil && slices.ContainsFunc(r.Findings, func(f Finding) bool {
+		return f.Severity == SeverityError
+	})
+}
+
+// Errors returns all findings with SeverityError.
+func (r *Report) Errors() []Finding {
+	return r.BySeverity(SeverityError)
+}
+
+// BySeverity returns all findings with the given severity.
+func (r *Report) BySeverity(severity Severity) []Finding {
+	return r.filter(func(f Finding) bool { return f.Severity == severity })
+}
+
+// ByCode returns all findings with the given code.
+func (r *Report) ByCode(code FindingCode) []Finding {
+	return r.filter(func(f Finding) bool { return f.Code == code })
+}
+
+// filter returns all findings for which fn returns true.
+func (r *Report) filter(fn func(f Finding) bool) []Finding {
+	if r == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, f := range r.Findings {
+		if fn(f) {
+			findings = append(findings, f)
+		}
+	}
+
+	return findings
+}
+
+// err returns a ReportError with the sentinel error if the report has errors, otherwise nil.
+func (r *Report) err(sentinel error) error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return &ReportError{Err: sentinel, Report: r}
+}
+
+// ReportError is returned by Parse and the Check methods if the report has findings with SeverityError.
+// It matches its sentinel error (e.g. ErrForbiddenFiles or ErrSfvValidationFailed) and the errors of all
+// findings with errors.Is, use errors.As to get the complete report.
+type ReportError struct {
+	// Err is the sentinel error of the parse or check.
+	Err error
+	// Report holds all findings, including warnings.
+	Report *Report
+}
+
+// Error implements the error interface.
+func (e *ReportError) Error() string {
+	findings := e.Report.Errors()
+
+	messages := make([]string, 0, len(findings))
+	for _, f := range findings {
+		messages = append(messages, f.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", e.Err, strings.Join(messages, "; "))
+}
+
+// Unwrap returns the sentinel error and all findings with SeverityError.
+func (e *ReportError) Unwrap() []error {
+	errs := []error{e.Err}
+	for _, f := range e.Report.Errors() {
+		errs = append(errs, f)
+	}
+	return errs
+}
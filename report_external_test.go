@@ -0,0 +1,188 @@
+package release_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findingCodes(findings []release.Finding) []release.FindingCode {
+	codes := make([]release.FindingCode, 0, len(findings))
+	for _, f := range findings {
+		codes = append(codes, f.Code)
+	}
+	return codes
+}
+
+func TestParse_Report(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Test.Release-Group/test.mkv":      []byte("content\n"),
+		"Test.Release-Group/test.nzb":      []byte("nzb\n"),
+		"Test.Release-Group/empty.txt":     {},
+		"Test.Release-Group/bad name.nfo":  []byte("nfo\n"),
+		"Test.Release-Group/Empty/":        nil,
+		"Test.Release-Group/Sample/s.mkv":  []byte("sample\n"),
+		"Test.Release-Group/Subs/test.idx": []byte("idx\n"),
+	})
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse(tmpDir + "/Test.Release-Group")
+	require.NotNil(t, rel)
+
+	// the sentinel and the errors of all findings match
+	assert.ErrorIs(t, err, release.ErrForbiddenFiles)
+	assert.ErrorIs(t, err, release.ErrForbiddenExtension)
+	assert.ErrorIs(t, err, release.ErrEmptyFile)
+	assert.ErrorIs(t, err, release.ErrEmptyFolder)
+	assert.ErrorIs(t, err, release.ErrForbiddenCharacters)
+
+	var reportErr *release.ReportError
+	require.True(t, errors.As(err, &reportErr))
+	assert.Same(t, rel.Report, reportErr.Report)
+
+	assert.ElementsMatch(t, []release.FindingCode{
+		release.CodeForbiddenExtension, release.CodeEmptyFile, release.CodeForbiddenCharacters, release.CodeEmptyFolder,
+	}, findingCodes(rel.Report.Findings))
+	assert.Len(t, rel.Report.Errors(), len(rel.ForbiddenFiles))
+
+	for _, f := range rel.Report.Findings {
+		assert.Equal(t, release.SeverityError, f.Severity)
+		assert.NotEmpty(t, f.Path)
+		assert.NotEmpty(t, f.Message)
+	}
+
+	empty := rel.Report.ByCode(release.CodeEmptyFile)
+	require.Len(t, empty, 1)
+	assert.ErrorIs(t, empty[0], release.ErrEmptyFile)
+
+	t.Run("warnings don't fail", func(t *testing.T) {
+		ruleset, err := release.LoadRuleset([]byte(`
+name: warn
+rules:
+  - id: no-txt
+    severity: warning
+    forbid_files:
+      extensions: [".txt"]
+`))
+		require.NoError(t, err)
+
+		tmpDir := t.TempDir()
+		setupTestDir(t, tmpDir, map[string][]byte{
+			"Test.Release-Group/test.mkv": []byte("content\n"),
+			"Test.Release-Group/test.txt": []byte("text\n"),
+		})
+
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithRuleset(ruleset).Build()
+		require.NoError(t, err)
+
+		rel, err := releaseService.Parse(tmpDir + "/Test.Release-Group")
+		require.NoError(t, err)
+
+		assert.False(t, rel.Report.HasErrors())
+		warnings := rel.Report.BySeverity(release.SeverityWarning)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, release.FindingCode("no-txt"), warnings[0].Code)
+	})
+}
+
+func TestReportError(t *testing.T) {
+	tests := []struct {
+		sentinel, other error
+		finding         release.Finding
+	}{
+		{
+			sentinel: release.ErrForbiddenFiles,
+			other:    release.ErrIncompletePack,
+			finding: release.Finding{
+				Code: release.CodeEmptyFile, Severity: release.SeverityError, Path: "Test.Release-Group/empty.txt",
+				Message: release.ErrEmptyFile.Error(), Err: release.ErrEmptyFile,
+			},
+		},
+		{
+			sentinel: release.ErrIncompletePack,
+			other:    release.ErrForbiddenFiles,
+			finding: release.Finding{
+				Code: release.CodeMissingEpisode, Severity: release.SeverityError,
+				Message: release.ErrMissingEpisode.Error(), Err: release.ErrMissingEpisode,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentinel.Error(), func(t *testing.T) {
+			report := &release.Report{Findings: []release.Finding{tt.finding}}
+
+			var err error = &release.ReportError{Err: tt.sentinel, Report: report}
+
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.ErrorIs(t, err, tt.finding.Err)
+			assert.NotErrorIs(t, err, tt.other)
+			assert.NotEqual(t, tt.sentinel, err, "the sentinel is wrapped")
+
+			var reportErr *release.ReportError
+			require.True(t, errors.As(err, &reportErr))
+			assert.Same(t, report, reportErr.Report)
+		})
+	}
+}
+
+func TestRelease_CheckSFVReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"test.r00": []byte("test-content-1\n"),
+		"test.r01": []byte("broken-content\n"),
+		"test.rar": []byte("test-content-3\n"),
+		"test.sfv": []byte("test.rar e4f6bb59\ntest.r00 d6c0d9db\ntest.r01 fded8a18\ntest.r02 ffffffff\n"),
+	})
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse(tmpDir)
+	require.NoError(t, err)
+
+	// all problems are found in one pass
+	report, err := releaseService.CheckSFVReport(rel, false)
+	require.NoError(t, err)
+	assert.Equal(t, []release.FindingCode{release.CodeMissingFile, release.CodeSfvMismatch}, findingCodes(report.Findings))
+	assert.ErrorIs(t, report.Findings[0], os.ErrNotExist)
+
+	err = releaseService.CheckSFV(rel, false)
+	assert.ErrorIs(t, err, release.ErrSfvValidationFailed)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	var reportErr *release.ReportError
+	require.True(t, errors.As(err, &reportErr))
+	assert.Len(t, reportErr.Report.Errors(), 2)
+}
+
+func TestRelease_CheckZipReport(t *testing.T) {
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse("testdata/Zipped.Missing.File.Release-Group")
+	require.NoError(t, err)
+
+	report, err := releaseService.CheckZipReport(rel, false)
+	require.NoError(t, err)
+	assert.Equal(t, []release.FindingCode{release.CodeZipCountMismatch}, findingCodes(report.Findings))
+
+	rel, err = releaseService.Parse("testdata/Zipped.Release-Group")
+	require.NoError(t, err)
+
+	report, err = releaseService.CheckZipReport(rel, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Findings)
+}
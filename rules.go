@@ -0,0 +1,809 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/f4n4t/go-dtree"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrRuleViolation is the error wrapped by every violation of a rule without its own error.
+	ErrRuleViolation = errors.New("rule violation")
+
+	// ErrInvalidRuleset is the error returned when a ruleset can't be loaded.
+	ErrInvalidRuleset = errors.New("invalid ruleset")
+)
+
+// Severity is the severity of a rule violation.
+type Severity string
+
+const (
+	// SeverityInfo is only informational.
+	SeverityInfo Severity = "info"
+	// SeverityWarning should be looked at, but does not reject the release.
+	SeverityWarning Severity = "warning"
+	// SeverityError rejects the release, file rules with this severity are added to the forbidden files by Parse.
+	SeverityError Severity = "error"
+)
+
+// Ruleset is a named list of rules that can be evaluated against a parsed release.
+type Ruleset struct {
+	Name string `json:"name" yaml:"name"`
+	// Version and Effective identify a version of a published ruleset, e.g. the scene rules.
+	Version   string    `json:"version" yaml:"version"`
+	Effective time.Time `json:"effective" yaml:"effective"`
+	// When restricts the whole ruleset to matching releases, nil matches every release.
+	When  *Condition `json:"when" yaml:"when"`
+	Rules []Rule     `json:"rules" yaml:"rules"`
+}
+
+// Rule is a single acceptance rule.
+// Require is checked once per release, Forbid is checked for every file and folder of the release.
+// Both only apply if the release matches When.
+type Rule struct {
+	// ID identifies the rule in violations, it has to be unique in the ruleset.
+	ID string `json:"id" yaml:"id"`
+	// Description is a human-readable explanation of the rule.
+	Description string `json:"description" yaml:"description"`
+	// Paragraph is the paragraph of the published ruleset the rule is taken from.
+	Paragraph string `json:"paragraph" yaml:"paragraph"`
+	// Severity is the severity of the violations, defaults to SeverityError.
+	Severity Severity `json:"severity" yaml:"severity"`
+	// When restricts the rule to matching releases, nil matches every release.
+	When *Condition `json:"when" yaml:"when"`
+	// Require must be true for the release.
+	Require *Condition `json:"require" yaml:"require"`
+	// Forbid is violated by every file and folder that matches.
+	Forbid *FileCondition `json:"forbid_files" yaml:"forbid_files"`
+	// err is the error of the violations, builtin rules use the existing sentinel errors.
+	err error
+}
+
+// Condition is a condition on the whole release, all set fields have to match.
+type Condition struct {
+	// Sections matches if the section of the release is one of the given sections.
+	Sections []Section `json:"sections" yaml:"sections"`
+	// Name is a regex for the release name.
+	Name string `json:"name" yaml:"name"`
+	// NameLength matches the length of the release name.
+	NameLength *CountRange `json:"name_length" yaml:"name_length"`
+	// Extensions matches the count of files per extension, e.g. {".sfv": {min: 1}}.
+	Extensions map[string]CountRange `json:"extensions" yaml:"extensions"`
+	// Size matches the total size of the release.
+	Size *SizeRange `json:"size" yaml:"size"`
+	// Files matches the count of all files.
+	Files *CountRange `json:"files" yaml:"files"`
+	// Path is a regex that has to match the path (relative to the release, slash separated) of any file or folder.
+	Path string `json:"path" yaml:"path"`
+	// PathCounts match the count of files and folders with a matching path, e.g. exactly one nfo in the root.
+	PathCounts []PathCount `json:"path_counts" yaml:"path_counts"`
+	// Volumes matches the size of the archive volumes (.rar, .rXX, .sXX), all volumes except the last one need
+	// the same size inside the range. Releases without archive volumes always match.
+	Volumes *SizeRange `json:"volumes" yaml:"volumes"`
+	// NFO matches if the release has (or has no) NFO file.
+	NFO *bool `json:"nfo" yaml:"nfo"`
+	// IMDb matches if an IMDb ID was found (or not).
+	IMDb *bool `json:"imdb" yaml:"imdb"`
+	// MediaInfo holds conditions on the mediainfo tracks, no mediainfo never matches.
+	MediaInfo []MediaInfoCondition `json:"mediainfo" yaml:"mediainfo"`
+	// Pre is a condition on the pre information.
+	Pre *PreCondition `json:"pre" yaml:"pre"`
+	// Anime matches if the release has (or has no) anime or fansub name (see Info.Anime).
+	Anime *bool `json:"anime" yaml:"anime"`
+	// Not must not match, e.g. to exclude UHD releases from a ruleset for HD releases.
+	Not *Condition `json:"not" yaml:"not"`
+
+	nameRegex, pathRegex *regexp.Regexp
+}
+
+// PathCount matches the count of files and folders whose path matches the regex.
+type PathCount struct {
+	Path       string `json:"path" yaml:"path"`
+	CountRange `yaml:",inline"`
+
+	pathRegex *regexp.Regexp
+}
+
+// FileCondition is a condition on a single file or folder, all set fields have to match.
+type FileCondition struct {
+	// Extensions matches if the extension is one of the given extensions (case-insensitive, with the dot).
+	Extensions []string `json:"extensions" yaml:"extensions"`
+	// Name is a regex for the base name.
+	Name string `json:"name" yaml:"name"`
+	// ExceptName is a regex for the base name of files that never match, e.g. the allowed files of a release.
+	ExceptName string `json:"except_name" yaml:"except_name"`
+	// NameLength matches the length of the base name.
+	NameLength *CountRange `json:"name_length" yaml:"name_length"`
+	// Path is a regex for the path relative to the release, slash separated.
+	Path string `json:"path" yaml:"path"`
+	// Size matches the size of the file.
+	Size *SizeRange `json:"size" yaml:"size"`
+	// Dirs enables matching folders, only Name, NameLength and Path are checked for them.
+	Dirs bool `json:"dirs" yaml:"dirs"`
+
+	nameRegex, exceptNameRegex, pathRegex *regexp.Regexp
+}
+
+// MediaInfoCondition matches if any mediainfo track of the given type matches.
+type MediaInfoCondition struct {
+	// Track is the track type, e.g. "Video" or "Audio".
+	Track MediaInfoType `json:"track" yaml:"track"`
+	// Field is the mediainfo field name, e.g. "Format" or "Height".
+	Field string `json:"field" yaml:"field"`
+	// Pattern is a regex for the value of the field.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Min and Max are limits for numeric values.
+	Min *float64 `json:"min" yaml:"min"`
+	Max *float64 `json:"max" yaml:"max"`
+
+	patternRegex *regexp.Regexp
+}
+
+// PreCondition is a condition on the pre information, all set fields have to match.
+type PreCondition struct {
+	// Found matches if pre information was found (or not).
+	Found *bool `json:"found" yaml:"found"`
+	// Nuked matches if the release is nuked (or not).
+	Nuked *bool `json:"nuked" yaml:"nuked"`
+	// Section is a regex for the section of the pre, no pre never matches.
+	Section string `json:"section" yaml:"section"`
+
+	sectionRegex *regexp.Regexp
+}
+
+// CountRange is an inclusive range of counts, nil limits are not checked.
+type CountRange struct {
+	Min *int `json:"min" yaml:"min"`
+	Max *int `json:"max" yaml:"max"`
+}
+
+// SizeRange is an inclusive range of sizes, nil limits are not checked.
+type SizeRange struct {
+	Min *ByteSize `json:"min" yaml:"min"`
+	Max *ByteSize `json:"max" yaml:"max"`
+}
+
+// ByteSize is a size in bytes, it can be loaded from a number or a string like "50MB" or "1.5GiB".
+type ByteSize int64
+
+// byteSizeRegex matches a human-readable size.
+var byteSizeRegex = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)\s*([kmgt]i?)?b?\s*$`)
+
+// ParseByteSize parses a size like "50MB", "1.5GiB" or "1024". Decimal and binary units are both base 1024.
+func ParseByteSize(s string) (ByteSize, error) {
+	m := byteSizeRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	if unit := strings.TrimSuffix(strings.ToLower(m[2]), "i"); unit != "" {
+		value *= float64(int64(1) << (10 * (strings.Index("kmgt", unit) + 1)))
+	}
+
+	return ByteSize(value), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	size, err := ParseByteSize(node.Value)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	size, err := ParseByteSize(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// Violation is a single violation of a rule.
+type Violation struct {
+	// RuleID is the ID of the violated rule.
+	RuleID string `json:"rule_id"`
+	// Severity is the severity of the rule.
+	Severity Severity `json:"severity"`
+	// Description is the description of the rule.
+	Description string `json:"description"`
+	// Paragraph is the paragraph of the published ruleset.
+	Paragraph string `json:"paragraph,omitempty"`
+	// Path is the FullPath of the violating file or folder, empty for release rules.
+	Path string `json:"path,omitempty"`
+	// Err is the sentinel error of the rule, ErrRuleViolation for rules without their own error.
+	Err error `json:"-"`
+}
+
+// Error implements the error interface.
+func (v Violation) Error() string {
+	if v.Path != "" {
+		return fmt.Sprintf("%s: %s (%s)", v.Err, v.RuleID, v.Path)
+	}
+	return fmt.Sprintf("%s: %s", v.Err, v.RuleID)
+}
+
+// Unwrap returns the sentinel error of the rule.
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// Default rule IDs, they are used for the forbidden files of Parse.
+const (
+	RuleForbiddenCharacters = "forbidden-characters"
+	RuleEmptyFile           = "empty-file"
+	RuleForbiddenExtension  = "forbidden-extension"
+
+	// RuleForbiddenCharactersAnime replaces RuleForbiddenCharacters for anime releases.
+	RuleForbiddenCharactersAnime = "forbidden-characters-anime"
+)
+
+// defaultRuleset is the DefaultRuleset used by the services, it is shared and must not be modified.
+var defaultRuleset = sync.OnceValue(DefaultRuleset)
+
+// DefaultRuleset returns the builtin ruleset used by Parse for the forbidden files.
+// It checks for bad characters (Regexes.BadChars, anime releases allow the characters of fansub names),
+// empty files and the ForbiddenExtensions.
+func DefaultRuleset() *Ruleset {
+	var emptySize ByteSize
+	isAnime, notAnime := true, false
+
+	return &Ruleset{
+		Name: "default",
+		Rules: []Rule{
+			{
+				ID:          RuleForbiddenCharacters,
+				Description: "file and folder names must not contain forbidden characters",
+				Severity:    SeverityError,
+				When:        &Condition{Anime: &notAnime},
+				Forbid: &FileCondition{
+					Dirs: true, Name: Regexes.BadChars.String(), nameRegex: Regexes.BadChars,
+				},
+				err: ErrForbiddenCharacters,
+			},
+			{
+				ID:          RuleForbiddenCharactersAnime,
+				Description: "file and folder names of anime releases must not contain forbidden characters",
+				Severity:    SeverityError,
+				When:        &Condition{Anime: &isAnime},
+				Forbid: &FileCondition{
+					Dirs: true, Name: animeRegexes.badChars.String(), nameRegex: animeRegexes.badChars,
+				},
+				err: ErrForbiddenCharacters,
+			},
+			{
+				ID:          RuleEmptyFile,
+				Description: "files must not be empty",
+				Severity:    SeverityError,
+				Forbid:      &FileCondition{Size: &SizeRange{Max: &emptySize}},
+				err:         ErrEmptyFile,
+			},
+			{
+				ID:          RuleForbiddenExtension,
+				Description: "files must not have a forbidden extension",
+				Severity:    SeverityError,
+				Forbid:      &FileCondition{Extensions: slices.Clone(ForbiddenExtensions)},
+				err:         ErrForbiddenExtension,
+			},
+		},
+	}
+}
+
+// LoadRuleset loads a ruleset from YAML or JSON and compiles all patterns.
+func LoadRuleset(data []byte) (*Ruleset, error) {
+	var rs Ruleset
+
+	if err := yaml.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRuleset, err)
+	}
+
+	if err := rs.compile(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRuleset, err)
+	}
+
+	return &rs, nil
+}
+
+// LoadRulesetFile loads a ruleset from a YAML or JSON file.
+func LoadRulesetFile(filePath string) (*Ruleset, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read ruleset: %w", err)
+	}
+
+	return LoadRuleset(data)
+}
+
+// compile validates the rules and compiles all regexes.
+func (rs *Ruleset) compile() error {
+	if err := rs.When.compile(); err != nil {
+		return fmt.Errorf("ruleset %s: %w", rs.Name, err)
+	}
+
+	ids := make(map[string]struct{}, len(rs.Rules))
+
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+
+		if rule.ID == "" {
+			return fmt.Errorf("rule %d: missing id", i)
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return fmt.Errorf("rule %s: duplicate id", rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+
+		switch rule.Severity {
+		case "":
+			rule.Severity = SeverityError
+		case SeverityInfo, SeverityWarning, SeverityError:
+		default:
+			return fmt.Errorf("rule %s: invalid severity %q", rule.ID, rule.Severity)
+		}
+
+		if rule.Require == nil && rule.Forbid == nil {
+			return fmt.Errorf("rule %s: needs require or forbid_files", rule.ID)
+		}
+
+		for _, c := range []*Condition{rule.When, rule.Require} {
+			if err := c.compile(); err != nil {
+				return fmt.Errorf("rule %s: %w", rule.ID, err)
+			}
+		}
+
+		if err := rule.Forbid.compile(); err != nil {
+			return fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// compile compiles the regexes of the condition.
+func (c *Condition) compile() error {
+	if c == nil {
+		return nil
+	}
+
+	var err error
+
+	if c.nameRegex, err = compilePattern(c.Name); err != nil {
+		return err
+	}
+
+	if c.pathRegex, err = compilePattern(c.Path); err != nil {
+		return err
+	}
+
+	for i := range c.PathCounts {
+		if c.PathCounts[i].pathRegex, err = compilePattern(c.PathCounts[i].Path); err != nil {
+			return err
+		}
+	}
+
+	for i := range c.MediaInfo {
+		if c.MediaInfo[i].patternRegex, err = compilePattern(c.MediaInfo[i].Pattern); err != nil {
+			return err
+		}
+	}
+
+	if c.Pre != nil {
+		if c.Pre.sectionRegex, err = compilePattern(c.Pre.Section); err != nil {
+			return err
+		}
+	}
+
+	return c.Not.compile()
+}
+
+// compile compiles the regexes of the file condition.
+func (fc *FileCondition) compile() error {
+	if fc == nil {
+		return nil
+	}
+
+	var err error
+
+	if fc.nameRegex, err = compilePattern(fc.Name); err != nil {
+		return err
+	}
+
+	if fc.exceptNameRegex, err = compilePattern(fc.ExceptName); err != nil {
+		return err
+	}
+
+	fc.pathRegex, err = compilePattern(fc.Path)
+
+	return err
+}
+
+// compilePattern compiles a regex, an empty pattern returns nil.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern %q: %w", pattern, err)
+	}
+
+	return re, nil
+}
+
+// Applies reports whether the release matches the When condition of the ruleset.
+func (rs *Ruleset) Applies(rel *Info) bool {
+	return rs.When == nil || rs.When.matches(rel)
+}
+
+// Evaluate checks all rules against the release and returns the violations in the order of the rules.
+// A ruleset that does not apply to the release has no violations.
+func (rs *Ruleset) Evaluate(rel *Info) []Violation {
+	if !rs.Applies(rel) {
+		return nil
+	}
+
+	var violations []Violation
+
+	for _, rule := range rs.Rules {
+		if rule.When != nil && !rule.When.matches(rel) {
+			continue
+		}
+
+		if rule.Require != nil && !rule.Require.matches(rel) {
+			violations = append(violations, rule.violation(""))
+		}
+
+		if rule.Forbid != nil && rel.Root != nil {
+			walkNodes(rel.Root, func(node *dtree.Node) {
+				if rule.Forbid.matches(rel.relPath(node.FullPath), node.Info) {
+					violations = append(violations, rule.violation(node.FullPath))
+				}
+			})
+		}
+	}
+
+	return violations
+}
+
+// fileViolations returns the violations of the file rules without a when condition or with a when condition
+// that is known before the files are walked (see Condition.knownBeforeWalk). It is used by Parse while walking
+// the release.
+func (rs *Ruleset) fileViolations(rel *Info, relPath string, fileInfo *dtree.FileInfo, fullPath string) []Violation {
+	var violations []Violation
+
+	for _, rule := range rs.Rules {
+		if rule.Forbid == nil || !rule.When.knownBeforeWalk() || rule.When != nil && !rule.When.matches(rel) {
+			continue
+		}
+
+		if rule.Forbid.matches(relPath, fileInfo) {
+			violations = append(violations, rule.violation(fullPath))
+		}
+	}
+
+	return violations
+}
+
+// violation creates a violation of the rule.
+func (r Rule) violation(fullPath string) Violation {
+	err := r.err
+	if err == nil {
+		err = ErrRuleViolation
+	}
+
+	return Violation{
+		RuleID:      r.ID,
+		Severity:    r.Severity,
+		Description: r.Description,
+		Paragraph:   r.Paragraph,
+		Path:        fullPath,
+		Err:         err,
+	}
+}
+
+// knownBeforeWalk reports whether the condition only depends on the release name (Name, NameLength, Anime and
+// Not), which is known before Parse walks the files. A nil condition is always known.
+func (c *Condition) knownBeforeWalk() bool {
+	if c == nil {
+		return true
+	}
+
+	return len(c.Sections) == 0 && len(c.Extensions) == 0 && c.Size == nil && c.Files == nil && c.Path == "" &&
+		len(c.PathCounts) == 0 && c.Volumes == nil && c.NFO == nil && c.IMDb == nil && len(c.MediaInfo) == 0 &&
+		c.Pre == nil && c.Not.knownBeforeWalk()
+}
+
+// matches checks the condition against the release.
+func (c *Condition) matches(rel *Info) bool {
+	if len(c.Sections) > 0 && !slices.Contains(c.Sections, rel.Section) {
+		return false
+	}
+
+	if c.Not != nil && c.Not.matches(rel) {
+		return false
+	}
+
+	if c.nameRegex != nil && !c.nameRegex.MatchString(rel.Name) {
+		return false
+	}
+
+	if c.NameLength != nil && !c.NameLength.contains(len(rel.Name)) {
+		return false
+	}
+
+	for ext, countRange := range c.Extensions {
+		if !countRange.contains(rel.Extensions[strings.ToLower(ext)]) {
+			return false
+		}
+	}
+
+	if c.Size != nil && !c.Size.contains(rel.Size) {
+		return false
+	}
+
+	if c.Files != nil {
+		files := 0
+		for _, count := range rel.Extensions {
+			files += count
+		}
+		if !c.Files.contains(files) {
+			return false
+		}
+	}
+
+	if c.pathRegex != nil && !anyNode(rel.Root, func(node *dtree.Node) bool {
+		return c.pathRegex.MatchString(rel.relPath(node.FullPath))
+	}) {
+		return false
+	}
+
+	for _, pc := range c.PathCounts {
+		count := 0
+		if rel.Root != nil {
+			walkNodes(rel.Root, func(node *dtree.Node) {
+				if node != rel.Root && pc.pathRegex.MatchString(rel.relPath(node.FullPath)) {
+					count++
+				}
+			})
+		}
+		if !pc.contains(count) {
+			return false
+		}
+	}
+
+	if c.Volumes != nil && !volumesMatch(rel, *c.Volumes) {
+		return false
+	}
+
+	if c.NFO != nil && *c.NFO != (rel.NFO != nil) {
+		return false
+	}
+
+	if c.Anime != nil && *c.Anime != (rel.Anime != nil) {
+		return false
+	}
+
+	if c.IMDb != nil && *c.IMDb != (rel.ImdbID > 0) {
+		return false
+	}
+
+	for _, mc := range c.MediaInfo {
+		if !mc.matches(rel.MediaInfo) {
+			return false
+		}
+	}
+
+	if c.Pre != nil && !c.Pre.matches(rel.PreInfo) {
+		return false
+	}
+
+	return true
+}
+
+// matches checks the file condition against a single file or folder.
+func (fc *FileCondition) matches(relPath string, fileInfo *dtree.FileInfo) bool {
+	if fileInfo.IsDir && !fc.Dirs {
+		return false
+	}
+
+	if fc.nameRegex != nil && !fc.nameRegex.MatchString(fileInfo.Name) {
+		return false
+	}
+
+	if fc.NameLength != nil && !fc.NameLength.contains(len(fileInfo.Name)) {
+		return false
+	}
+
+	if fc.pathRegex != nil && !fc.pathRegex.MatchString(relPath) {
+		return false
+	}
+
+	if fileInfo.IsDir {
+		// only the name and path apply to folders
+		return fc.nameRegex != nil || fc.NameLength != nil || fc.pathRegex != nil
+	}
+
+	if fc.exceptNameRegex != nil && fc.exceptNameRegex.MatchString(fileInfo.Name) {
+		return false
+	}
+
+	if len(fc.Extensions) > 0 && !slices.ContainsFunc(fc.Extensions, func(ext string) bool {
+		return strings.EqualFold(ext, fileInfo.Extension)
+	}) {
+		return false
+	}
+
+	if fc.Size != nil && !fc.Size.contains(fileInfo.Size) {
+		return false
+	}
+
+	return true
+}
+
+// matches checks if any track of the mediainfo matches.
+func (mc MediaInfoCondition) matches(mi *MediaInfo) bool {
+	if mi == nil {
+		return false
+	}
+
+	for _, track := range mi.Media.Tracks {
+		if mc.Track != "" && MediaInfoType(track.Type) != mc.Track {
+			continue
+		}
+
+		value, ok := mediaInfoField(track, mc.Field)
+		if !ok {
+			continue
+		}
+
+		if mc.patternRegex != nil && !mc.patternRegex.MatchString(value) {
+			continue
+		}
+
+		if mc.Min != nil || mc.Max != nil {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil || (mc.Min != nil && number < *mc.Min) || (mc.Max != nil && number > *mc.Max) {
+				continue
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// mediaInfoField returns the value of a track field by its mediainfo (json) or go name.
+func mediaInfoField(track MediaInfoTrack, field string) (string, bool) {
+	v := reflect.ValueOf(track)
+	t := v.Type()
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Type.Kind() != reflect.String {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if strings.EqualFold(jsonName, field) || strings.EqualFold(f.Name, field) {
+			return v.Field(i).String(), true
+		}
+	}
+
+	return "", false
+}
+
+// matches checks the pre condition against the pre information.
+func (pc *PreCondition) matches(pre *Pre) bool {
+	if pc.Found != nil && *pc.Found != (pre != nil) {
+		return false
+	}
+
+	if pc.Nuked != nil && *pc.Nuked != (pre != nil && pre.Nuke != "") {
+		return false
+	}
+
+	if pc.sectionRegex != nil && (pre == nil || !pc.sectionRegex.MatchString(pre.Section)) {
+		return false
+	}
+
+	return true
+}
+
+// volumesMatch checks that all archive volumes of each folder, except the last one, share one size inside the range.
+func volumesMatch(rel *Info, sizeRange SizeRange) bool {
+	if rel.Root == nil {
+		return true
+	}
+
+	volumesByDir := make(map[*dtree.Node][]*dtree.Node)
+
+	walkNodes(rel.Root, func(node *dtree.Node) {
+		if !node.Info.IsDir && rarFilesPattern.MatchString(strings.ToLower(node.Info.Extension)) {
+			volumesByDir[node.Parent] = append(volumesByDir[node.Parent], node)
+		}
+	})
+
+	for _, volumes := range volumesByDir {
+		sizes := make([]int64, len(volumes))
+		for i, volume := range volumes {
+			sizes[i] = volume.Info.Size
+		}
+
+		// the last volume is the smallest one and can have any size
+		slices.Sort(sizes)
+		slices.Reverse(sizes)
+		sizes = sizes[:len(sizes)-1]
+
+		for _, size := range sizes {
+			if size != sizes[0] || !sizeRange.contains(size) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// contains checks if the count is inside the range.
+func (r CountRange) contains(count int) bool {
+	return (r.Min == nil || count >= *r.Min) && (r.Max == nil || count <= *r.Max)
+}
+
+// contains checks if the size is inside the range.
+func (r SizeRange) contains(size int64) bool {
+	return (r.Min == nil || size >= int64(*r.Min)) && (r.Max == nil || size <= int64(*r.Max))
+}
+
+// relPath returns the slash separated path of a FullPath relative to the release, the root is an empty string.
+func (rel *Info) relPath(fullPath string) string {
+	if rel.IsSingleFile {
+		return path.Base(strings.ReplaceAll(fullPath, `\`, "/"))
+	}
+
+	relPath := strings.TrimPrefix(fullPath, rel.BaseDir)
+
+	return strings.Trim(strings.ReplaceAll(relPath, `\`, "/"), "/")
+}
+
+// walkNodes calls fn for the node and all its children.
+func walkNodes(node *dtree.Node, fn func(node *dtree.Node)) {
+	fn(node)
+	for _, child := range node.Children {
+		walkNodes(child, fn)
+	}
+}
+
+// anyNode reports whether fn returns true for the node or any of its children.
+func anyNode(node *dtree.Node, fn func(node *dtree.Node) bool) bool {
+	if node == nil {
+		return false
+	}
+	if fn(node) {
+		return true
+	}
+	return slices.ContainsFunc(node.Children, func(child *dtree.Node) bool {
+		return anyNode(child, fn)
+	})
+}
@@ -0,0 +1,299 @@
+package release_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleset = `
+name: site
+rules:
+  - id: tv-needs-sfv
+    description: tv releases need an sfv
+    when:
+      sections: [tv, tv-pack]
+    require:
+      extensions:
+        .sfv: {min: 1}
+  - id: no-big-sample
+    description: no sample over 50MB
+    severity: warning
+    forbid_files:
+      path: (?i)(^|/)sample/
+      size: {min: 50MB}
+  - id: movie-imdb
+    description: movies need an nfo with an imdb id
+    when:
+      sections: [movies]
+    require:
+      nfo: true
+      imdb: true
+  - id: no-url
+    description: no .url files
+    forbid_files:
+      extensions: [.URL]
+  - id: nuked
+    severity: info
+    require:
+      pre:
+        nuked: false
+`
+
+func TestLoadRuleset(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		rs, err := release.LoadRuleset([]byte(testRuleset))
+		require.NoError(t, err)
+
+		assert.Equal(t, "site", rs.Name)
+		require.Len(t, rs.Rules, 5)
+		assert.Equal(t, release.SeverityError, rs.Rules[0].Severity, "default severity")
+		assert.Equal(t, release.ByteSize(50*1024*1024), *rs.Rules[1].Forbid.Size.Min)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rs, err := release.LoadRuleset([]byte(`{"name": "json", "rules": [
+			{"id": "max-size", "severity": "warning", "require": {"size": {"max": "1.5GiB"}, "files": {"max": 100}}}
+		]}`))
+		require.NoError(t, err)
+
+		require.Len(t, rs.Rules, 1)
+		assert.Equal(t, release.ByteSize(1536*1024*1024), *rs.Rules[0].Require.Size.Max)
+		assert.Equal(t, 100, *rs.Rules[0].Require.Files.Max)
+	})
+
+	invalid := map[string]string{
+		"syntax":            "rules: [",
+		"missing id":        "rules: [{require: {nfo: true}}]",
+		"duplicate id":      "rules: [{id: a, require: {nfo: true}}, {id: a, require: {nfo: true}}]",
+		"invalid severity":  "rules: [{id: a, severity: fatal, require: {nfo: true}}]",
+		"no condition":      "rules: [{id: a}]",
+		"invalid regex":     "rules: [{id: a, forbid_files: {name: '('}}]",
+		"invalid size":      "rules: [{id: a, forbid_files: {size: {min: 5XB}}}]",
+		"invalid pre regex": "rules: [{id: a, require: {pre: {section: '['}}}]",
+	}
+
+	for desc, data := range invalid {
+		t.Run(desc, func(t *testing.T) {
+			_, err := release.LoadRuleset([]byte(data))
+			assert.ErrorIs(t, err, release.ErrInvalidRuleset)
+		})
+	}
+}
+
+func TestRuleset_Evaluate(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	rs, err := release.LoadRuleset([]byte(testRuleset))
+	require.NoError(t, err)
+
+	t.Run("tv pack", func(t *testing.T) {
+		name := "Show.S01.German.1080p.WEB.x264-Group"
+		rel, err := releaseService.ParseListing(name, []release.ListingEntry{
+			{Path: "show.s01e01.mkv", Size: 1000},
+			{Path: "show.s01e02.mkv", Size: 1000},
+			{Path: "Sample/show.sample.mkv", Size: 60 * 1024 * 1024},
+			{Path: "Sample/small.mkv", Size: 10},
+			{Path: "link.url", Size: 10},
+		})
+		require.ErrorIs(t, err, release.ErrForbiddenFiles)
+
+		violations := rs.Evaluate(rel)
+
+		ids := make([]string, len(violations))
+		for i, v := range violations {
+			ids[i] = v.RuleID
+		}
+		assert.Equal(t, []string{"tv-needs-sfv", "no-big-sample", "no-url"}, ids)
+
+		assert.Empty(t, violations[0].Path)
+		assert.Equal(t, name+"/Sample/show.sample.mkv", violations[1].Path)
+		assert.Equal(t, release.SeverityWarning, violations[1].Severity)
+		assert.ErrorIs(t, violations[2], release.ErrRuleViolation)
+	})
+
+	t.Run("movie", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Movie.2020.German.1080p.BluRay.x264-Group", []release.ListingEntry{
+			{Path: "movie.mkv", Size: 1000},
+		})
+		require.NoError(t, err)
+
+		violations := rs.Evaluate(rel)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "movie-imdb", violations[0].RuleID)
+
+		rel.ImdbID = 123
+		rel.NFO = &release.NFOFile{Name: "movie.nfo"}
+		rel.PreInfo = &release.Pre{Nuke: "bad.release"}
+
+		violations = rs.Evaluate(rel)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "nuked", violations[0].RuleID)
+		assert.Equal(t, release.SeverityInfo, violations[0].Severity)
+	})
+
+	t.Run("mediainfo", func(t *testing.T) {
+		mediaRules, err := release.LoadRuleset([]byte(`
+rules:
+  - id: min-height
+    require:
+      mediainfo:
+        - {track: Video, field: Height, min: 1080}
+        - {track: Audio, field: Format, pattern: '(?i)^(ac-?3|dts)$'}
+`))
+		require.NoError(t, err)
+
+		rel := &release.Info{}
+		assert.Len(t, mediaRules.Evaluate(rel), 1, "no mediainfo")
+
+		rel.MediaInfo = &release.MediaInfo{Media: release.Media{Tracks: []release.MediaInfoTrack{
+			{Type: "Video", Height: "1080"},
+			{Type: "Audio", Format: "AC-3"},
+		}}}
+		assert.Empty(t, mediaRules.Evaluate(rel))
+
+		rel.MediaInfo.Media.Tracks[0].Height = "720"
+		assert.Len(t, mediaRules.Evaluate(rel), 1)
+	})
+
+	t.Run("not", func(t *testing.T) {
+		notRules, err := release.LoadRuleset([]byte(`
+rules:
+  - id: hd-nfo
+    when:
+      name: '(?i)[._]bluray[._]'
+      not:
+        name: '(?i)[._]2160p[._]'
+    require:
+      nfo: true
+`))
+		require.NoError(t, err)
+
+		assert.Len(t, notRules.Evaluate(&release.Info{Name: "Movie.2020.BluRay.x264-Group"}), 1)
+		assert.Empty(t, notRules.Evaluate(&release.Info{Name: "Movie.2020.2160p.BluRay.x265-Group"}))
+	})
+}
+
+func TestDefaultRuleset(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.ParseListing("Movie.2020.German.1080p.BluRay.x264-Group", []release.ListingEntry{
+		{Path: "movie.mkv", Size: 1000},
+		{Path: "empty.nfo", Size: 0},
+		{Path: "release.NZB", Size: 10},
+		{Path: "bad name!.txt", Size: 10},
+		{Path: "bad dir!/file.txt", Size: 10},
+	})
+	require.ErrorIs(t, err, release.ErrForbiddenFiles)
+
+	violations := release.DefaultRuleset().Evaluate(rel)
+	require.Len(t, violations, len(rel.ForbiddenFiles))
+
+	for _, v := range violations {
+		found := false
+		for _, ff := range rel.ForbiddenFiles {
+			if ff.FullPath == v.Path && errors.Is(v, ff.Error) {
+				found = true
+			}
+		}
+		assert.True(t, found, "violation %s not in forbidden files", v)
+	}
+
+	assert.ElementsMatch(t, []string{"empty.nfo", "release.NZB", "bad name!.txt", "bad dir!"},
+		rel.ForbiddenFiles.Names())
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := json.Marshal(release.DefaultRuleset())
+		require.NoError(t, err)
+
+		loaded, err := release.LoadRuleset(data)
+		require.NoError(t, err)
+
+		ids := func(violations []release.Violation) []string {
+			ids := make([]string, 0, len(violations))
+			for _, v := range violations {
+				ids = append(ids, v.RuleID+" "+v.Path)
+			}
+			return ids
+		}
+
+		// the loaded rules have no sentinel errors, but find the same files
+		assert.Equal(t, ids(violations), ids(loaded.Evaluate(rel)))
+	})
+}
+
+func TestServiceBuilder_WithRuleset(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	rs, err := release.LoadRuleset([]byte(`
+rules:
+  - id: no-txt
+    forbid_files: {extensions: [.txt]}
+  - id: no-proof
+    severity: warning
+    forbid_files: {name: '(?i)^proof$', dirs: true}
+  - id: no-jpg-for-movies
+    when: {name: '(?i)[._](19|20)\d{2}[._]', not: {anime: true}}
+    forbid_files: {extensions: [.jpg]}
+  - id: no-mkv-for-movies
+    when: {sections: [movies]}
+    forbid_files: {extensions: [.mkv]}
+`))
+	require.NoError(t, err)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithRuleset(rs).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.ParseListing("Movie.2020.German.1080p.BluRay.x264-Group", []release.ListingEntry{
+		{Path: "movie.mkv", Size: 1000},
+		{Path: "notes.txt", Size: 10},
+		{Path: "release.nzb", Size: 0},
+		{Path: "Proof/proof.jpg", Size: 10},
+	})
+	require.ErrorIs(t, err, release.ErrForbiddenFiles)
+
+	// the default rules are replaced, warnings are only logged and the section is not known while parsing
+	assert.ElementsMatch(t, []string{"notes.txt", "proof.jpg"}, rel.ForbiddenFiles.Names())
+	for _, ff := range rel.ForbiddenFiles {
+		assert.ErrorIs(t, ff.Error, release.ErrRuleViolation)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]release.ByteSize{
+		"0":       0,
+		"1024":    1024,
+		"1k":      1024,
+		"50MB":    50 * 1024 * 1024,
+		"1.5GiB":  1536 * 1024 * 1024,
+		" 2 tb ":  2 * 1024 * 1024 * 1024 * 1024,
+		"100 b":   100,
+		"3 mi":    3 * 1024 * 1024,
+		"0.5 KiB": 512,
+	}
+
+	for input, expected := range tests {
+		got, err := release.ParseByteSize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, got, input)
+	}
+
+	for _, input := range []string{"", "MB", "-1", "1 PB", "1,5GB"} {
+		_, err := release.ParseByteSize(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,142 @@
+package release
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoSceneRuleset is the error returned when no scene ruleset applies to a release.
+var ErrNoSceneRuleset = errors.New("no scene ruleset")
+
+//go:embed scene_rules/*.yaml
+var sceneRulesFS embed.FS
+
+// loadSceneRulesets loads all embedded scene rulesets once, sorted by name, effective date and version.
+var loadSceneRulesets = sync.OnceValues(func() ([]*Ruleset, error) {
+	files, err := fs.Glob(sceneRulesFS, "scene_rules/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	rulesets := make([]*Ruleset, 0, len(files))
+
+	for _, file := range files {
+		data, err := sceneRulesFS.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		rs, err := LoadRuleset(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		rulesets = append(rulesets, rs)
+	}
+
+	slices.SortFunc(rulesets, func(a, b *Ruleset) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if c := a.Effective.Compare(b.Effective); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Version, b.Version)
+	})
+
+	return rulesets, nil
+})
+
+// SceneRulesets returns all versions of the builtin scene rulesets (TV x264/x265, HD/UHD movies, MP3, FLAC and 0day),
+// sorted by name, effective date and version. The rules are condensed from the published rulesets, every rule names
+// its paragraph. The rulesets are shared and must not be modified.
+func SceneRulesets() []*Ruleset {
+	rulesets, err := loadSceneRulesets()
+	if err != nil {
+		// the rulesets are embedded, so this can only happen with a broken build
+		panic(fmt.Sprintf("load scene rulesets: %v", err))
+	}
+
+	return slices.Clone(rulesets)
+}
+
+// ComplianceReport is the result of checking a release against a scene ruleset.
+type ComplianceReport struct {
+	// Release is the name of the release.
+	Release string `json:"release"`
+	// Ruleset and Version identify the scene ruleset the release was judged against.
+	Ruleset string `json:"ruleset"`
+	Version string `json:"version"`
+	// JudgedAt is the time used to select the version, the pre time or zero if no pre was found.
+	JudgedAt time.Time `json:"judged_at"`
+	// Violations holds all violated rules, each one names its paragraph.
+	Violations []Violation `json:"violations"`
+}
+
+// Compliant reports whether no rule with SeverityError is violated.
+func (r *ComplianceReport) Compliant() bool {
+	return !slices.ContainsFunc(r.Violations, func(v Violation) bool {
+		return v.Severity == SeverityError
+	})
+}
+
+// CheckSceneRules checks the release against the scene ruleset that applied at its pre time.
+// Without pre information the latest version is used. ErrNoSceneRuleset is returned if no ruleset applies.
+func CheckSceneRules(rel *Info) (*ComplianceReport, error) {
+	var at time.Time
+	if rel.PreInfo != nil {
+		at = rel.PreInfo.Time
+	}
+
+	return CheckSceneRulesAt(rel, at)
+}
+
+// CheckSceneRulesAt checks the release against the scene ruleset that applied at the given time.
+// A zero time uses the latest version, a time before the first version uses the first version.
+func CheckSceneRulesAt(rel *Info, at time.Time) (*ComplianceReport, error) {
+	rs := sceneRulesetAt(SceneRulesets(), rel, at)
+	if rs == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoSceneRuleset, rel.Name)
+	}
+
+	return &ComplianceReport{
+		Release:    rel.Name,
+		Ruleset:    rs.Name,
+		Version:    rs.Version,
+		JudgedAt:   at,
+		Violations: rs.Evaluate(rel),
+	}, nil
+}
+
+// sceneRulesetAt selects the version of every ruleset that applied at the given time and returns the first one
+// that applies to the release. The rulesets have to be sorted by name, effective date and version.
+func sceneRulesetAt(rulesets []*Ruleset, rel *Info, at time.Time) *Ruleset {
+	for i := 0; i < len(rulesets); {
+		// all versions of the same ruleset
+		j := i + 1
+		for j < len(rulesets) && rulesets[j].Name == rulesets[i].Name {
+			j++
+		}
+
+		selected := rulesets[i]
+		for _, rs := range rulesets[i:j] {
+			if at.IsZero() || !rs.Effective.After(at) {
+				selected = rs
+			}
+		}
+
+		if selected.Applies(rel) {
+			return selected
+		}
+
+		i = j
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package release_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mb = 1024 * 1024
+
+func TestSceneRulesets(t *testing.T) {
+	rulesets := release.SceneRulesets()
+	require.NotEmpty(t, rulesets)
+
+	names := make(map[string]struct{})
+	for _, rs := range rulesets {
+		names[rs.Name] = struct{}{}
+
+		assert.NotEmpty(t, rs.Version, rs.Name)
+		assert.False(t, rs.Effective.IsZero(), rs.Name)
+		assert.NotNil(t, rs.When, rs.Name)
+		assert.NotEmpty(t, rs.Rules, rs.Name)
+
+		for _, rule := range rs.Rules {
+			assert.NotEmpty(t, rule.Paragraph, "%s %s: missing paragraph", rs.Name, rule.ID)
+		}
+	}
+
+	for _, name := range []string{"tv-x264", "tv-x265", "movies-hd", "movies-uhd", "mp3", "flac", "0day"} {
+		assert.Contains(t, names, name)
+	}
+}
+
+func TestCheckSceneRules(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+	require.NoError(t, err)
+
+	tvFiles := []release.ListingEntry{
+		{Path: "show.s01e01.nfo", Size: 1000},
+		{Path: "show.s01e01.sfv", Size: 100},
+		{Path: "show.s01e01.rar", Size: 200 * mb},
+		{Path: "show.s01e01.r00", Size: 200 * mb},
+		{Path: "show.s01e01.r01", Size: 10 * mb},
+		{Path: "Sample/show.s01e01.sample.mkv", Size: 10 * mb},
+	}
+
+	violationIDs := func(report *release.ComplianceReport) []string {
+		ids := make([]string, 0, len(report.Violations))
+		for _, v := range report.Violations {
+			ids = append(ids, v.RuleID+" "+v.Paragraph)
+		}
+		return ids
+	}
+
+	t.Run("versions by pre time", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Show.S01E01.720p.HDTV.x264-Group", tvFiles)
+		require.NoError(t, err)
+
+		rel.PreInfo = &release.Pre{Time: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+		report, err := release.CheckSceneRules(rel)
+		require.NoError(t, err)
+		assert.Equal(t, "tv-x264", report.Ruleset)
+		assert.Equal(t, "2020", report.Version)
+		assert.Equal(t, rel.PreInfo.Time, report.JudgedAt)
+		assert.Empty(t, report.Violations)
+		assert.True(t, report.Compliant())
+
+		// the volumes were too big in 2016
+		rel.PreInfo.Time = time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		report, err = release.CheckSceneRules(rel)
+		require.NoError(t, err)
+		assert.Equal(t, "2016", report.Version)
+		assert.Equal(t, []string{"rar-volumes 5.1"}, violationIDs(report))
+		assert.Equal(t, "5.1", report.Violations[0].Paragraph)
+		assert.False(t, report.Compliant())
+
+		// without pre the latest version is used
+		rel.PreInfo = nil
+
+		report, err = release.CheckSceneRules(rel)
+		require.NoError(t, err)
+		assert.Equal(t, "2020", report.Version)
+		assert.True(t, report.JudgedAt.IsZero())
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Show.S01E01.720p.HDTV.x264-Group", []release.ListingEntry{
+			{Path: "show.s01e01.sfv", Size: 100},
+			{Path: "show.s01e01.rar", Size: 200 * mb},
+			{Path: "show.s01e01.r00", Size: 100 * mb},
+			{Path: "show.s01e01.r01", Size: 10 * mb},
+			{Path: "Sample/show sample.mkv", Size: 10 * mb},
+			{Path: "Sample2/show.sample.mkv", Size: 10 * mb},
+		})
+		require.ErrorIs(t, err, release.ErrForbiddenFiles)
+
+		report, err := release.CheckSceneRulesAt(rel, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"file-characters 19.2", "rar-volumes 17.1", "nfo 18.1"}, violationIDs(report))
+		assert.Equal(t, rel.Name+"/Sample/show sample.mkv", report.Violations[0].Path)
+	})
+
+	t.Run("dirname", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Show.720p.HDTV.x264-Group", tvFiles)
+		require.NoError(t, err)
+		rel.Section = release.TV
+
+		report, err := release.CheckSceneRulesAt(rel, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dirname-format 19.1"}, violationIDs(report))
+	})
+
+	t.Run("movies by source", func(t *testing.T) {
+		movieFiles := []release.ListingEntry{
+			{Path: "movie.2020.bluray.x264-group.nfo", Size: 1000},
+			{Path: "movie.2020.bluray.x264-group.mkv", Size: 4000 * mb},
+		}
+
+		rel, err := releaseService.ParseListing("Movie.2020.BluRay.x264-Group", movieFiles)
+		require.NoError(t, err)
+		rel.Section = release.Movies
+
+		report, err := release.CheckSceneRules(rel)
+		require.NoError(t, err)
+		assert.Equal(t, "movies-hd", report.Ruleset)
+
+		rel, err = releaseService.ParseListing("Movie.2020.UHD.BluRay.x265-Group", movieFiles)
+		require.NoError(t, err)
+		rel.Section = release.Movies
+
+		// UHD releases are not judged by the HD ruleset
+		_, err = release.CheckSceneRules(rel)
+		assert.ErrorIs(t, err, release.ErrNoSceneRuleset)
+	})
+
+	t.Run("mp3", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Artist-Title-WEB-2021-Group", []release.ListingEntry{
+			{Path: "00-artist-title-web-2021.nfo", Size: 100},
+			{Path: "00-artist-title-web-2021.sfv", Size: 100},
+			{Path: "01-artist-song.mp3", Size: 5 * mb},
+			{Path: "CD2/02-artist-song.mp3", Size: 5 * mb},
+		})
+		require.NoError(t, err)
+		require.Equal(t, release.AudioMP3, rel.Section)
+
+		report, err := release.CheckSceneRules(rel)
+		require.NoError(t, err)
+		assert.Equal(t, "mp3", report.Ruleset)
+		assert.Equal(t, []string{"files 5.1", "subfolders 5.3"}, violationIDs(report))
+	})
+
+	t.Run("no ruleset", func(t *testing.T) {
+		rel, err := releaseService.ParseListing("Some.Ebook-Group", []release.ListingEntry{{Path: "book.epub", Size: 10}})
+		require.NoError(t, err)
+
+		_, err = release.CheckSceneRules(rel)
+		assert.ErrorIs(t, err, release.ErrNoSceneRuleset)
+	})
+}
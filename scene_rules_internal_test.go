@@ -0,0 +1,47 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneRulesetAt(t *testing.T) {
+	date := func(year int) time.Time {
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tv := &Condition{Sections: []Section{TV}}
+
+	rulesets := []*Ruleset{
+		{Name: "movies", Version: "2020", Effective: date(2020), When: &Condition{Sections: []Section{Movies}}},
+		{Name: "tv", Version: "2016", Effective: date(2016), When: tv},
+		{Name: "tv", Version: "2020", Effective: date(2020), When: tv},
+	}
+
+	rel := &Info{Section: TV}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "no time uses the latest version", want: "2020"},
+		{name: "between the versions", at: date(2018), want: "2016"},
+		{name: "after the latest version", at: date(2022), want: "2020"},
+		{name: "before the first version", at: date(2010), want: "2016"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := sceneRulesetAt(rulesets, rel, tt.at)
+			if assert.NotNil(t, rs) {
+				assert.Equal(t, "tv", rs.Name)
+				assert.Equal(t, tt.want, rs.Version)
+			}
+		})
+	}
+
+	assert.Nil(t, sceneRulesetAt(rulesets, &Info{Section: AudioMP3}, time.Time{}))
+}
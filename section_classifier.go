@@ -0,0 +1,156 @@
+package release
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// builtinSections holds all sections of the builtin section detection.
+var builtinSections = []Section{
+	AppsMisc, AppsMacOS, AppsLinux, AppsWindows,
+	GamesWindows, GamesMacOS, GamesLinux, GamesPlaystation, GamesNintendo, GamesXbox,
+	AudioBooks, AudioFLAC, AudioMP3, AudioVideo,
+	Movies, TV, TVPack, Sport, Anime,
+	XXX, XXXImagesets, XXXClips, XXXDVD, XXXPack, XXXMovies,
+	Tutorials, Mobile, Ebooks, Unknown,
+}
+
+// SectionClassifier determines the section of a release by its name and the pre information, which is nil if
+// none was found. It returns Unknown to defer to the next classifier of the chain.
+type SectionClassifier interface {
+	ClassifySection(name string, preInfo *Pre) Section
+}
+
+// SectionClassifierFunc is a function that implements SectionClassifier.
+type SectionClassifierFunc func(name string, preInfo *Pre) Section
+
+// ClassifySection implements SectionClassifier.
+func (f SectionClassifierFunc) ClassifySection(name string, preInfo *Pre) Section {
+	return f(name, preInfo)
+}
+
+// SectionClassifierChain asks its classifiers in order and returns the first section that is not Unknown.
+type SectionClassifierChain []SectionClassifier
+
+// ClassifySection implements SectionClassifier.
+func (c SectionClassifierChain) ClassifySection(name string, preInfo *Pre) Section {
+	for _, classifier := range c {
+		if section := classifier.ClassifySection(name, preInfo); section != Unknown {
+			return section
+		}
+	}
+	return Unknown
+}
+
+// WithSectionClassifier adds a classifier that is asked before the builtin section detection, classifiers are
+// asked in the order they were added. Sections that are not builtin have to be added with WithCustomSection.
+func (s *ServiceBuilder) WithSectionClassifier(classifier SectionClassifier) *ServiceBuilder {
+	s.service.sectionClassifiers = append(s.service.sectionClassifiers, classifier)
+	return s
+}
+
+// WithFallbackSectionClassifier adds a classifier that is only asked if the builtin section detection returns
+// Unknown, e.g. to detect sections the builtin detection doesn't know.
+func (s *ServiceBuilder) WithFallbackSectionClassifier(classifier SectionClassifier) *ServiceBuilder {
+	s.service.fallbackClassifiers = append(s.service.fallbackClassifiers, classifier)
+	return s
+}
+
+// WithCustomSection adds a section that can be returned by the classifiers, mediaInfo enables the mediainfo
+// generation for its releases like for Movies or TV.
+func (s *ServiceBuilder) WithCustomSection(section Section, mediaInfo bool) *ServiceBuilder {
+	if s.service.customSections == nil {
+		s.service.customSections = make(map[Section]bool)
+	}
+	s.service.customSections[section] = mediaInfo
+	return s
+}
+
+// Sections returns all sections of the service, the builtin sections followed by the custom sections.
+func (s *Service) Sections() []Section {
+	sections := slices.Clone(builtinSections)
+	for section := range s.customSections {
+		if !slices.Contains(sections, section) {
+			sections = append(sections, section)
+		}
+	}
+	slices.Sort(sections[len(builtinSections):])
+	return sections
+}
+
+// DefaultSectionClassifier returns the builtin section detection as chain: the detection by the patterns of the
+// name and the pre section, followed by the fallback patterns for tutorials, mobile and games.
+func (s *Service) DefaultSectionClassifier() SectionClassifierChain {
+	return s.defaultSectionClassifier(nil)
+}
+
+// defaultSectionClassifier returns the chain of DefaultSectionClassifier, which records the steps in the trace.
+// The trace may be nil.
+func (s *Service) defaultSectionClassifier(t *sectionTrace) SectionClassifierChain {
+	d := s.newSectionDetection(t)
+
+	return SectionClassifierChain{
+		SectionClassifierFunc(func(name string, preInfo *Pre) Section {
+			return s.detectPrimarySection(strings.ToLower(name), preSectionOf(preInfo), d)
+		}),
+		SectionClassifierFunc(func(name string, _ *Pre) Section {
+			return s.detectFallbackSection(strings.ToLower(name), d)
+		}),
+	}
+}
+
+// preSectionOf returns the lowercase section of the pre information, empty without pre information.
+func preSectionOf(preInfo *Pre) string {
+	if preInfo == nil {
+		return ""
+	}
+	return strings.ToLower(preInfo.Section)
+}
+
+// classifySection asks the classifiers added with WithSectionClassifier, the builtin section detection and the
+// fallback classifiers in this order. Sections that are unknown to the service are logged and skipped.
+// The steps are recorded in the trace, which may be nil.
+func (s *Service) classifySection(name string, preInfo *Pre, t *sectionTrace) Section {
+	if section := s.askClassifiers("sectionClassifier", s.sectionClassifiers, name, preInfo, t); section != Unknown {
+		return section
+	}
+
+	if section := s.defaultSectionClassifier(t).ClassifySection(name, preInfo); section != Unknown {
+		return section
+	}
+
+	return s.askClassifiers("fallbackClassifier", s.fallbackClassifiers, name, preInfo, t)
+}
+
+// askClassifiers returns the first section of the classifiers that is known to the service.
+func (s *Service) askClassifiers(rule string, classifiers []SectionClassifier, name string, preInfo *Pre, t *sectionTrace) Section {
+	for i, classifier := range classifiers {
+		section := classifier.ClassifySection(name, preInfo)
+		known := section != Unknown && section != "" && s.isKnownSection(section)
+
+		t.classifier(fmt.Sprintf("%s[%d]", rule, i), section, known)
+
+		if known {
+			return section
+		}
+
+		if section != Unknown && section != "" {
+			s.log.Warn().Str("name", name).Str("section", string(section)).
+				Msg("classifier returned a section that was not added with WithCustomSection")
+		}
+	}
+
+	return Unknown
+}
+
+// isKnownSection checks if the section is builtin or was added with WithCustomSection.
+func (s *Service) isKnownSection(section Section) bool {
+	_, custom := s.customSections[section]
+	return custom || slices.Contains(builtinSections, section)
+}
+
+// hasMediaInfo checks if mediainfo is generated for releases of the section.
+func (s *Service) hasMediaInfo(section Section) bool {
+	return s.customSections[section] || slices.Contains(mediaInfoSections, section)
+}
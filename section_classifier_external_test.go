@@ -0,0 +1,84 @@
+package release_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SectionClassifiers(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	const (
+		comics  release.Section = "comics"
+		podcast release.Section = "podcast"
+	)
+
+	comicClassifier := release.SectionClassifierFunc(func(name string, _ *release.Pre) release.Section {
+		if strings.Contains(strings.ToLower(name), ".comic.") {
+			return comics
+		}
+		return release.Unknown
+	})
+
+	podcastClassifier := release.SectionClassifierFunc(func(name string, preInfo *release.Pre) release.Section {
+		if preInfo != nil && preInfo.Section == "PODCAST" {
+			return podcast
+		}
+		return release.Unknown
+	})
+
+	unregisteredClassifier := release.SectionClassifierFunc(func(name string, _ *release.Pre) release.Section {
+		return "unregistered"
+	})
+
+	releaseService, err := release.NewServiceBuilder().
+		WithSectionClassifier(unregisteredClassifier).
+		WithSectionClassifier(comicClassifier).
+		WithFallbackSectionClassifier(podcastClassifier).
+		WithCustomSection(comics, false).
+		WithCustomSection(podcast, false).
+		Build()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		releaseName string
+		preSection  string
+		expected    release.Section
+	}{
+		{"before the builtin detection", "Some.Comic.2020.1080p.WEB.H264-GROUP", "", comics},
+		{"builtin detection", "Free.Guy.2021.1080p.WEB.H264-NAISU", "", release.Movies},
+		{"fallback after unknown", "Some.Show.Episode.42", "PODCAST", podcast},
+		{"builtin wins over the fallback", "Game.of.Thrones.S01E01.1080p.BluRay.x264-ROVERS", "PODCAST", release.TV},
+		{"all classifiers unknown", "Was.Willst.Du", "", release.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var preInfo *release.Pre
+			if tt.preSection != "" {
+				preInfo = &release.Pre{Section: tt.preSection}
+			}
+			assert.Equal(t, tt.expected, releaseService.ParseSection(tt.releaseName, preInfo))
+		})
+	}
+
+	t.Run("default chain", func(t *testing.T) {
+		chain := releaseService.DefaultSectionClassifier()
+		assert.Equal(t, release.Movies, chain.ClassifySection("Some.Comic.2020.1080p.WEB.H264-GROUP", nil))
+		assert.Equal(t, release.Tutorials, chain.ClassifySection("Udemy.Learn.Go", nil))
+	})
+
+	t.Run("sections", func(t *testing.T) {
+		sections := releaseService.Sections()
+		assert.Contains(t, sections, release.Movies)
+		assert.Equal(t, []release.Section{comics, podcast}, sections[len(sections)-2:])
+		assert.NotContains(t, sections, release.Section("unregistered"))
+	})
+}
@@ -0,0 +1,169 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Confidence of the section by the kind of step that decided it.
+const (
+	// confidencePattern is a section decided by a specific pattern or a classifier.
+	confidencePattern = 0.9
+	// confidencePre is a section decided by the section of the pre information.
+	confidencePre = 0.8
+	// confidenceFallback is a section decided by the fallback patterns.
+	confidenceFallback = 0.7
+	// confidenceDefault is a section chosen because no more specific pattern matched, e.g. Movies for a video.
+	confidenceDefault = 0.5
+)
+
+// SectionExplanation explains the result of ParseSection.
+type SectionExplanation struct {
+	// Section is the detected section, the same as ParseSection returns.
+	Section Section `json:"section"`
+	// Confidence is between 0 (Unknown) and 1, a section decided by a specific pattern has a higher confidence
+	// than a default like Movies for a video without TV patterns.
+	Confidence float64 `json:"confidence"`
+	// Trace holds all checked rules in the order they were checked, the last one decided the section.
+	Trace []SectionStep `json:"trace"`
+}
+
+// SectionStep is a single rule checked by the section detection.
+type SectionStep struct {
+	// Rule is the name of the pattern or check, e.g. "oldVideo", "noSport" or "sectionClassifier[0]".
+	Rule string `json:"rule"`
+	// Matched is true if the rule matched.
+	Matched bool `json:"matched"`
+	// Match is the matched text of a pattern or the section returned by a classifier.
+	Match string `json:"match,omitempty"`
+	// Value is the input of an informational step, e.g. the section of the pre information.
+	Value string `json:"value,omitempty"`
+	// Section is the section decided by this step.
+	Section Section `json:"section,omitempty"`
+}
+
+// String returns the step like `oldVideo matched "x264"`, `noSport skipped` or `preSection=flac`.
+func (step SectionStep) String() string {
+	var s string
+
+	switch {
+	case step.Value != "":
+		s = fmt.Sprintf("%s=%s", step.Rule, step.Value)
+	case step.Matched && step.Match != "":
+		s = fmt.Sprintf("%s matched %q", step.Rule, step.Match)
+	case step.Matched:
+		s = step.Rule + " matched"
+	case step.Match != "":
+		s = fmt.Sprintf("%s skipped %q", step.Rule, step.Match)
+	default:
+		s = step.Rule + " skipped"
+	}
+
+	if step.Section != "" {
+		s += " -> " + string(step.Section)
+	}
+
+	return s
+}
+
+// ExplainSection works like ParseSection, but also returns a confidence and the trace of all rules that were
+// checked to find the section.
+func (s *Service) ExplainSection(name string, preInfo *Pre) SectionExplanation {
+	t := &sectionTrace{}
+
+	if preSection := preSectionOf(preInfo); preSection != "" {
+		t.steps = append(t.steps, SectionStep{Rule: "preSection", Value: preSection})
+	}
+
+	section := s.classifySection(name, preInfo, t)
+
+	explanation := SectionExplanation{Section: section, Trace: t.steps}
+	if section != Unknown {
+		explanation.Confidence = t.confidence
+	}
+
+	return explanation
+}
+
+// sectionTrace records the steps of the section detection, all methods work on a nil trace without recording.
+type sectionTrace struct {
+	steps      []SectionStep
+	confidence float64
+}
+
+// matchAny checks the patterns of the rule against the value and records the first non-empty group or the whole
+// match of the first matching pattern.
+func (t *sectionTrace) matchAny(rule string, patterns []*regexp.Regexp, value string) bool {
+	if t == nil {
+		return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool {
+			return re.MatchString(value)
+		})
+	}
+
+	step := SectionStep{Rule: rule}
+
+	for _, re := range patterns {
+		m := re.FindStringSubmatch(value)
+		if m == nil {
+			continue
+		}
+
+		step.Matched, step.Match = true, m[0]
+		for _, group := range m[1:] {
+			if group != "" {
+				step.Match = group
+				break
+			}
+		}
+		break
+	}
+
+	t.steps = append(t.steps, step)
+
+	return step.Matched
+}
+
+// check records the result of a check without a pattern.
+func (t *sectionTrace) check(rule string, ok bool) bool {
+	if t != nil {
+		t.steps = append(t.steps, SectionStep{Rule: rule, Matched: ok})
+	}
+	return ok
+}
+
+// classifier records the section returned by a classifier.
+func (t *sectionTrace) classifier(rule string, section Section, known bool) {
+	if t == nil {
+		return
+	}
+
+	step := SectionStep{Rule: rule, Matched: known}
+	if section != Unknown {
+		step.Match = string(section)
+	}
+	if known {
+		step.Section = section
+		t.confidence = confidencePattern
+	}
+
+	t.steps = append(t.steps, step)
+}
+
+// decide sets the section on the last step, which matched, and returns the section.
+func (t *sectionTrace) decide(section Section, confidence float64) Section {
+	if t != nil && len(t.steps) > 0 {
+		t.steps[len(t.steps)-1].Section = section
+		t.confidence = confidence
+	}
+	return section
+}
+
+// fallback records the default section of a branch and returns it.
+func (t *sectionTrace) fallback(section Section) Section {
+	if t != nil {
+		t.steps = append(t.steps, SectionStep{Rule: "default", Matched: true, Section: section})
+		t.confidence = confidenceDefault
+	}
+	return section
+}
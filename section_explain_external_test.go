@@ -0,0 +1,121 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ExplainSection(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	releaseService, err := release.NewServiceBuilder().Build()
+	require.NoError(t, err)
+
+	trace := func(explanation release.SectionExplanation) []string {
+		steps := make([]string, 0, len(explanation.Trace))
+		for _, step := range explanation.Trace {
+			steps = append(steps, step.String())
+		}
+		return steps
+	}
+
+	t.Run("movie by default", func(t *testing.T) {
+		explanation := releaseService.ExplainSection("Die.Abenteurer.1967.German.1080p.BluRay.x264-DETAiLS", nil)
+
+		assert.Equal(t, release.Movies, explanation.Section)
+		assert.InDelta(t, 0.5, explanation.Confidence, 0.001)
+		assert.Equal(t, []string{
+			"anime skipped",
+			"xxxImageset skipped",
+			"musicSource skipped",
+			`oldVideo matched "1080p"`,
+			"xxx skipped",
+			"noSport skipped",
+			"sport skipped",
+			"mvidPreSection skipped",
+			"mvid skipped",
+			"tvPack skipped",
+			"tv skipped",
+			"default matched -> movies",
+		}, trace(explanation))
+	})
+
+	t.Run("pre section", func(t *testing.T) {
+		explanation := releaseService.ExplainSection("H.E.A.T-Freedom_Rock-2023_Version-24BIT-WEB-FLAC-2023-TiMES",
+			&release.Pre{Section: "FLAC"})
+
+		assert.Equal(t, release.AudioFLAC, explanation.Section)
+		assert.InDelta(t, 0.9, explanation.Confidence, 0.001)
+
+		steps := trace(explanation)
+		assert.Equal(t, "preSection=flac", steps[0])
+		assert.Equal(t, `flac matched "-flac-" -> flac`, steps[len(steps)-1])
+	})
+
+	t.Run("sport", func(t *testing.T) {
+		explanation := releaseService.ExplainSection("Football.Focus.2022.08.13.1080p.WEB.h264-GRP", nil)
+
+		assert.Equal(t, release.Sport, explanation.Section)
+		assert.Contains(t, trace(explanation), "noSport skipped")
+		assert.Contains(t, trace(explanation), "sport matched -> sport")
+
+		explanation = releaseService.ExplainSection("Football.Focus.Doku.2022.German.1080p.WEB.x264-GRP", nil)
+
+		assert.Equal(t, release.Movies, explanation.Section)
+		assert.Contains(t, trace(explanation), `noSport matched "doku"`)
+		assert.NotContains(t, trace(explanation), "sport skipped")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		explanation := releaseService.ExplainSection("Was.Willst.Du", nil)
+
+		assert.Equal(t, release.Unknown, explanation.Section)
+		assert.Zero(t, explanation.Confidence)
+		assert.Equal(t, []string{
+			"anime skipped",
+			"xxxImageset skipped",
+			"musicSource skipped",
+			"oldVideo skipped",
+			"ebook skipped",
+			"tutorial skipped",
+			"mobile skipped",
+			"game skipped",
+		}, trace(explanation))
+	})
+
+	t.Run("same section as the default chain", func(t *testing.T) {
+		chain := releaseService.DefaultSectionClassifier()
+
+		for _, name := range []string{
+			"Die.Abenteurer.1967.German.1080p.BluRay.x264-DETAiLS",
+			"Show.S01E02.German.720p.WEB.x264-Group",
+			"Artist-Title-WEB-2021-Group",
+			"Udemy.Learn.Go",
+			"Some.Random.Name",
+		} {
+			assert.Equal(t, chain.ClassifySection(name, nil), releaseService.ExplainSection(name, nil).Section, name)
+		}
+	})
+
+	t.Run("classifier", func(t *testing.T) {
+		classifierService, err := release.NewServiceBuilder().
+			WithFallbackSectionClassifier(release.SectionClassifierFunc(func(string, *release.Pre) release.Section {
+				return "podcast"
+			})).
+			WithCustomSection("podcast", false).
+			Build()
+		require.NoError(t, err)
+
+		explanation := classifierService.ExplainSection("Was.Willst.Du", nil)
+
+		assert.Equal(t, release.Section("podcast"), explanation.Section)
+		assert.InDelta(t, 0.9, explanation.Confidence, 0.001)
+		steps := trace(explanation)
+		assert.Equal(t, `fallbackClassifier[0] matched "podcast" -> podcast`, steps[len(steps)-1])
+	})
+}
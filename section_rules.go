@@ -0,0 +1,211 @@
+package release
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidSectionRules is the error returned when section rules can't be loaded.
+var ErrInvalidSectionRules = errors.New("invalid section rules")
+
+// sectionRulesData holds the embedded patterns of the builtin section detection.
+//
+//go:embed section_rules.yaml
+var sectionRulesData []byte
+
+// sectionRuleNames are the names of all rules checked by the builtin section detection.
+var sectionRuleNames = []string{
+	"anime", "xxxImageset", "musicSource", "oldVideo", "ebook",
+	"gameSection", "appsPreSection", "audioPreSection",
+	"tutorial", "mobile", "game",
+	"xxx", "imageSet", "clips", "dvd", "pack", "noSport", "mvidPreSection", "mvid", "tvPack", "tv",
+	"aBook", "flac", "videoCodec",
+	"xbox", "wii", "playStation", "crossplatform", "linux", "macOS",
+}
+
+// SectionRules holds the patterns of the builtin section detection by the name of the rule, e.g. "oldVideo" or
+// "tvPack". A rule matches if any of its patterns matches, the order of the rules is fixed by the section detection.
+type SectionRules struct {
+	// Version identifies the version of the rules file.
+	Version string `json:"version" yaml:"version"`
+	// Replace replaces the patterns of the rules instead of adding them when the rules are merged (see Merge).
+	Replace bool `json:"replace" yaml:"replace"`
+	// Rules holds the regexes of every rule.
+	Rules map[string][]string `json:"rules" yaml:"rules"`
+
+	patterns map[string][]*regexp.Regexp
+}
+
+// defaultSectionRules loads the embedded section rules once, they are checked by the tests.
+var defaultSectionRules = sync.OnceValue(func() *SectionRules {
+	rules, err := LoadSectionRules(sectionRulesData)
+	if err != nil {
+		panic(fmt.Sprintf("load embedded section rules: %v", err))
+	}
+
+	for _, name := range sectionRuleNames {
+		if len(rules.patterns[name]) == 0 {
+			panic(fmt.Sprintf("embedded section rules: missing rule %s", name))
+		}
+	}
+
+	return rules
+})
+
+// DefaultSectionRules returns a copy of the embedded section rules used by the builtin section detection.
+func DefaultSectionRules() *SectionRules {
+	return defaultSectionRules().clone()
+}
+
+// LoadSectionRules loads section rules from YAML or JSON and compiles all patterns.
+// Only the rules of the builtin section detection can be set, see DefaultSectionRules.
+func LoadSectionRules(data []byte) (*SectionRules, error) {
+	var rules SectionRules
+
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSectionRules, err)
+	}
+
+	if err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSectionRules, err)
+	}
+
+	return &rules, nil
+}
+
+// LoadSectionRulesFile loads section rules from a YAML or JSON file.
+func LoadSectionRulesFile(filePath string) (*SectionRules, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read section rules: %w", err)
+	}
+
+	return LoadSectionRules(data)
+}
+
+// compile validates the rule names and compiles all patterns.
+func (r *SectionRules) compile() error {
+	r.patterns = make(map[string][]*regexp.Regexp, len(r.Rules))
+
+	for _, name := range slices.Sorted(maps.Keys(r.Rules)) {
+		if !slices.Contains(sectionRuleNames, name) {
+			return fmt.Errorf("unknown rule %s", name)
+		}
+
+		for _, pattern := range r.Rules[name] {
+			re, err := compilePattern(pattern)
+			if err != nil {
+				return fmt.Errorf("rule %s: %w", name, err)
+			}
+			if re != nil {
+				r.patterns[name] = append(r.patterns[name], re)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Merge returns new rules with the patterns of the other rules added to the patterns of these rules.
+// If the other rules have Replace set, their rules replace the rules with the same name instead.
+// The version of the other rules wins if it is set.
+func (r *SectionRules) Merge(other *SectionRules) (*SectionRules, error) {
+	merged := r.clone()
+
+	if other == nil {
+		return merged, nil
+	}
+
+	// the rules could have been changed after they were loaded
+	other = other.clone()
+	if err := other.compile(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSectionRules, err)
+	}
+
+	if other.Version != "" {
+		merged.Version = other.Version
+	}
+
+	for name, patterns := range other.Rules {
+		if other.Replace {
+			merged.Rules[name] = slices.Clone(patterns)
+			merged.patterns[name] = slices.Clone(other.patterns[name])
+			continue
+		}
+
+		merged.Rules[name] = append(merged.Rules[name], patterns...)
+		merged.patterns[name] = append(merged.patterns[name], other.patterns[name]...)
+	}
+
+	return merged, nil
+}
+
+// clone returns a deep copy of the rules, the compiled patterns are shared.
+func (r *SectionRules) clone() *SectionRules {
+	c := &SectionRules{
+		Version: r.Version,
+		Replace: r.Replace,
+		Rules:   make(map[string][]string, len(r.Rules)),
+	}
+
+	for name, patterns := range r.Rules {
+		c.Rules[name] = slices.Clone(patterns)
+	}
+
+	if r.patterns != nil {
+		c.patterns = make(map[string][]*regexp.Regexp, len(r.patterns))
+		for name, patterns := range r.patterns {
+			c.patterns[name] = slices.Clone(patterns)
+		}
+	}
+
+	return c
+}
+
+// WithSectionRules merges the section rules into the rules of the service, which are the DefaultSectionRules if
+// no rules were set before. Without Replace the patterns are added to the existing rules, e.g. to detect a new
+// platform tag or music source, see SectionRules.Merge. Invalid rules are returned as an error by Build.
+func (s *ServiceBuilder) WithSectionRules(rules *SectionRules) *ServiceBuilder {
+	base := s.service.sectionRules
+	if base == nil {
+		base = defaultSectionRules()
+	}
+
+	merged, err := base.Merge(rules)
+	if err != nil {
+		s.err = errors.Join(s.err, err)
+		return s
+	}
+
+	s.service.sectionRules = merged
+	return s
+}
+
+// sectionDetection is a single run of the builtin section detection with the rules of the service.
+// The embedded trace is only recorded by ExplainSection.
+type sectionDetection struct {
+	*sectionTrace
+	rules *SectionRules
+}
+
+// newSectionDetection creates a section detection with the rules of the service and the trace, which may be nil.
+func (s *Service) newSectionDetection(t *sectionTrace) *sectionDetection {
+	rules := s.sectionRules
+	if rules == nil {
+		rules = defaultSectionRules()
+	}
+	return &sectionDetection{sectionTrace: t, rules: rules}
+}
+
+// match checks if any pattern of the rule matches the value.
+func (d *sectionDetection) match(rule string, value string) bool {
+	return d.matchAny(rule, d.rules.patterns[rule], value)
+}
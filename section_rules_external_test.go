@@ -0,0 +1,109 @@
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSectionRules(t *testing.T) {
+	rules := release.DefaultSectionRules()
+
+	assert.Equal(t, "2", rules.Version)
+	assert.NotEmpty(t, rules.Rules["oldVideo"])
+
+	// changing the copy does not change the rules of new services
+	rules.Rules["oldVideo"] = nil
+	assert.NotEmpty(t, release.DefaultSectionRules().Rules["oldVideo"])
+}
+
+func TestLoadSectionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: "version: \"2\"\nrules:\n  musicSource:\n    - '(?i)[_-]newsrc-'\n"},
+		{name: "unknown rule", data: "rules:\n  noSuchRule:\n    - 'abc'\n", wantErr: true},
+		{name: "invalid pattern", data: "rules:\n  tv:\n    - '(abc'\n", wantErr: true},
+		{name: "invalid yaml", data: "rules: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := release.LoadSectionRules([]byte(tt.data))
+			if tt.wantErr {
+				require.ErrorIs(t, err, release.ErrInvalidSectionRules)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "2", rules.Version)
+		})
+	}
+}
+
+func TestServiceBuilder_WithSectionRules(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	extendFile := filepath.Join(t.TempDir(), "extend.yaml")
+	require.NoError(t, os.WriteFile(extendFile, []byte("rules:\n  musicSource:\n    - '(?i)[_-](newsrc)-.*\\d{4}'\n"), 0644))
+
+	extend, err := release.LoadSectionRulesFile(extendFile)
+	require.NoError(t, err)
+
+	replace, err := release.LoadSectionRules([]byte("replace: true\nrules:\n  tvPack:\n    - '(?i)[._]season[._]\\d+[._]'\n"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		rules       []*release.SectionRules
+		releaseName string
+		expected    release.Section
+	}{
+		{name: "unknown source", releaseName: "Artist-Title-NEWSRC-2023-GRP", expected: release.Unknown},
+		{name: "extended source", rules: []*release.SectionRules{extend}, releaseName: "Artist-Title-NEWSRC-2023-GRP", expected: release.AudioMP3},
+		{name: "extension keeps patterns", rules: []*release.SectionRules{extend}, releaseName: "Artist-Title-WEB-2023-GRP", expected: release.AudioMP3},
+		{name: "replaced tv pack", rules: []*release.SectionRules{extend, replace}, releaseName: "Show.Season.1.1080p.WEB.h264-GRP", expected: release.TVPack},
+		{name: "replaced pattern", rules: []*release.SectionRules{replace}, releaseName: "Game.of.Thrones.S08.1080p.BluRay.x264-ROVERS", expected: release.TV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := release.NewServiceBuilder()
+			for _, rules := range tt.rules {
+				builder.WithSectionRules(rules)
+			}
+
+			releaseService, err := builder.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, releaseService.ParseSection(tt.releaseName, nil))
+		})
+	}
+
+	t.Run("invalid rules", func(t *testing.T) {
+		_, err := release.NewServiceBuilder().
+			WithSectionRules(&release.SectionRules{Rules: map[string][]string{"tvPack": {"(abc"}}}).
+			Build()
+		assert.ErrorIs(t, err, release.ErrInvalidSectionRules)
+	})
+}
+
+func TestSectionRules_Merge(t *testing.T) {
+	merged, err := release.DefaultSectionRules().Merge(&release.SectionRules{
+		Version: "custom",
+		Rules:   map[string][]string{"xbox": {"(?i)xsx"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "custom", merged.Version)
+	assert.Equal(t, []string{"(?i)xbox", "(?i)xsx"}, merged.Rules["xbox"])
+
+	_, err = release.DefaultSectionRules().Merge(&release.SectionRules{Rules: map[string][]string{"unknown": {"abc"}}})
+	assert.ErrorIs(t, err, release.ErrInvalidSectionRules)
+}
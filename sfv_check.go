@@ -4,14 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/f4n4t/go-release/pkg/progress"
-	"github.com/f4n4t/go-release/pkg/utils"
 )
 
 var (
@@ -51,56 +49,74 @@ func (sf sfvFiles) TotalSize() int64 {
 }
 
 // CheckSFV verifies the integrity of files against SFV checksums and logs the results.
-// It processes all ".sfv" files associated with the provided Info object.
+// It processes all ".sfv" files associated with the provided Info object. All sfv files are checked completely,
+// on failure a *ReportError is returned that matches ErrSfvValidationFailed and the errors of all findings.
 func (s *Service) CheckSFV(rel *Info, showProgress bool) error {
+	report, err := s.CheckSFVReport(rel, showProgress)
+	if err != nil {
+		return err
+	}
+
+	return report.err(ErrSfvValidationFailed)
+}
+
+// CheckSFVReport works like CheckSFV, but returns the invalid sfv files, missing files and CRC mismatches as report.
+// An error is only returned if the check can't continue, e.g. if the context is canceled.
+func (s *Service) CheckSFVReport(rel *Info, showProgress bool) (*Report, error) {
 	startTime := time.Now()
 
-	success := true
+	report := &Report{}
 
 	for _, sfv := range rel.Root.GetFiles(".sfv") {
 		s.log.Info().Str("sfvFile", sfv.Info.Name).Msg("starting sfv check")
 
-		passed, err := s.performSFVCheck(rel, sfv.FullPath, showProgress)
+		passed, err := s.performSFVCheck(rel, sfv.FullPath, showProgress, report)
 		if err != nil {
-			return fmt.Errorf("perform sfv check %s: %w", sfv.Info.Name, err)
+			return nil, fmt.Errorf("perform sfv check %s: %w", sfv.Info.Name, err)
 		}
 
 		if !passed {
 			s.log.Error().Str("sfvFile", sfv.Info.Name).Msg("check failed")
-			success = false
 			continue
 		}
 
 		s.log.Info().Str("sfvFile", sfv.Info.Name).Msg("check passed")
 	}
 
-	if !success {
-		return ErrSfvValidationFailed
-	}
-
 	s.log.Info().Str("dur", time.Since(startTime).String()).Msg("sfv checks complete")
 
-	return nil
+	return report, nil
 }
 
 // performSFVCheck checks the integrity of files listed in an SFV file by comparing their CRC values with local files.
-func (s *Service) performSFVCheck(rel *Info, sfvPath string, showProgress bool) (bool, error) {
+// Every problem is added to the report and the check continues with the next file.
+func (s *Service) performSFVCheck(rel *Info, sfvPath string, showProgress bool, report *Report) (bool, error) {
 	useParallelRead, err := s.useParallelRead(rel.Root.FullPath)
 	if err != nil {
 		return false, err
 	}
 
-	filesFromSFV, err := getFilesFromSFV(sfvPath)
-	if err != nil {
+	findings := len(report.Findings)
+
+	filesFromSFV, err := readSFVFiles(rel.files, sfvPath, report)
+	if errors.Is(err, ErrInvalidSfv) {
+		report.add(CodeInvalidSfv, SeverityError, sfvPath, err)
+		return false, nil
+	} else if err != nil {
 		return false, fmt.Errorf("get files from sfv: %w", err)
 	}
 
+	// missing files are already added to the report
+	passed := len(report.Findings) == findings
+
 	if len(filesFromSFV) == 0 {
-		return false, ErrEmptySfv
+		if passed {
+			report.add(CodeInvalidSfv, SeverityError, sfvPath, ErrEmptySfv)
+		}
+		return false, nil
 	}
 
 	var (
-		passed    = true
 		totalSize = filesFromSFV.TotalSize()
 		bar       = progress.NewProgressBar(showProgress, totalSize, true)
 	)
@@ -108,10 +124,17 @@ func (s *Service) performSFVCheck(rel *Info, sfvPath string, showProgress bool)
 	for _, sfvFile := range filesFromSFV {
 		localFile, err := rel.Root.GetFileByAbsolutePath(sfvFile.path)
 		if err != nil {
-			return false, fmt.Errorf("get file: %w", err)
+			report.add(CodeMissingFile, SeverityError, sfvFile.path, fmt.Errorf("get file: %w", err))
+			passed = false
+			continue
+		}
+
+		crcBuilder, err := rel.files.crcBuilder(localFile.FullPath, sfvFile.crc)
+		if err != nil {
+			return false, err
 		}
 
-		crcChecker := utils.NewCheckCRCBuilder(localFile.FullPath, sfvFile.crc).
+		crcChecker := crcBuilder.
 			WithParallelRead(useParallelRead).
 			WithProgressBar(bar).
 			WithContext(s.ctx).
@@ -125,6 +148,7 @@ func (s *Service) performSFVCheck(rel *Info, sfvPath string, showProgress bool)
 			}
 
 			s.log.Error().Err(err).Msg("verification failed")
+			report.add(CodeSfvMismatch, SeverityError, localFile.FullPath, err)
 			// continue to check every file
 		}
 	}
@@ -133,8 +157,14 @@ func (s *Service) performSFVCheck(rel *Info, sfvPath string, showProgress bool)
 }
 
 // getFilesFromSFV parses an SFV file, extracts file information and CRC values, and returns the corresponding sfvFiles.
-func getFilesFromSFV(sfvPath string) (sfvFiles, error) {
-	content, err := os.ReadFile(sfvPath)
+func getFilesFromSFV(files releaseFS, sfvPath string) (sfvFiles, error) {
+	return readSFVFiles(files, sfvPath, nil)
+}
+
+// readSFVFiles works like getFilesFromSFV, but if a report is given, entries that can't be found are added to it
+// instead of returning an error.
+func readSFVFiles(files releaseFS, sfvPath string, report *Report) (sfvFiles, error) {
+	content, err := files.readFile(sfvPath)
 	if err != nil {
 		return nil, fmt.Errorf("read sfv file: %w", err)
 	}
@@ -144,25 +174,29 @@ func getFilesFromSFV(sfvPath string) (sfvFiles, error) {
 		return nil, fmt.Errorf("%w: no matches found in sfv file", ErrInvalidSfv)
 	}
 
-	files := make(sfvFiles, 0, len(matches))
-	sfvDir := filepath.Dir(sfvPath)
+	entries := make(sfvFiles, 0, len(matches))
+	sfvDir := files.dir(sfvPath)
 
 	for _, match := range matches {
-		file, err := processSFVEntry(sfvDir, match[1], match[2])
+		file, err := processSFVEntry(files, sfvDir, match[1], match[2])
 		if err != nil {
-			return nil, err
+			if report == nil || !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			report.add(CodeMissingFile, SeverityError, files.join(sfvDir, match[1]), err)
+			continue
 		}
-		files = append(files, file)
+		entries = append(entries, file)
 	}
 
-	return files, nil
+	return entries, nil
 }
 
 // processSFVEntry parses an SFV entry, validates file existence, and creates an sfvFile object with metadata.
-func processSFVEntry(baseDir, fileName, crcStr string) (sfvFile, error) {
-	filePath := filepath.Join(baseDir, fileName)
+func processSFVEntry(files releaseFS, baseDir, fileName, crcStr string) (sfvFile, error) {
+	filePath := files.join(baseDir, fileName)
 
-	fInfo, err := os.Stat(filePath)
+	fInfo, err := files.stat(filePath)
 	if err != nil {
 		return sfvFile{}, fmt.Errorf("stat file %s: %w", fileName, err)
 	}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/f4n4t/go-release"
 	"github.com/stretchr/testify/assert"
@@ -72,7 +73,8 @@ func TestRelease_CheckSFV(t *testing.T) {
 			tempDir := t.TempDir()
 			setupTestDir(t, tempDir, tt.testFiles)
 
-			releaseService := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			require.NoError(t, err)
 
 			rel, err := releaseService.Parse(tempDir)
 			require.NoError(t, err)
@@ -82,6 +84,23 @@ func TestRelease_CheckSFV(t *testing.T) {
 		})
 	}
 
+	t.Run("CheckFromFS", func(t *testing.T) {
+		fsys := fstest.MapFS{}
+		for name, content := range validTest.testFiles {
+			fsys["Test.Release-Group/"+name] = &fstest.MapFile{Data: content}
+		}
+
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithParallelFileRead(1).Build()
+		require.NoError(t, err)
+
+		rel, err := releaseService.ParseFS(fsys, "Test.Release-Group")
+		require.NoError(t, err)
+		assert.NoError(t, releaseService.CheckSFV(rel, false))
+
+		fsys["Test.Release-Group/test.rar"] = &fstest.MapFile{Data: []byte("broken-content\n")}
+		assert.ErrorIs(t, releaseService.CheckSFV(rel, false), release.ErrSfvValidationFailed)
+	})
+
 	t.Run("CheckCancellation", func(t *testing.T) {
 		tempDir := t.TempDir()
 		setupTestDir(t, tempDir, validTest.testFiles)
@@ -90,7 +109,8 @@ func TestRelease_CheckSFV(t *testing.T) {
 		defer cancel()
 
 		// check if cancellation works
-		releaseService := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).WithContext(ctx).Build()
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).WithContext(ctx).Build()
+		require.NoError(t, err)
 
 		rel, err := releaseService.Parse(tempDir)
 		require.NoError(t, err)
@@ -66,7 +66,7 @@ func TestProcessSFVEntry(t *testing.T) {
 
 			tt.setupTestFile(t, filePath)
 
-			gotFile, err := processSFVEntry(tempDir, tt.fileName, tt.crcStr)
+			gotFile, err := processSFVEntry(releaseFS{}, tempDir, tt.fileName, tt.crcStr)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 				return
@@ -152,7 +152,7 @@ func TestGetFilesFromSFV(t *testing.T) {
 
 			sfvPath := filepath.Join(tempDir, tt.sfvName)
 
-			gotFiles, err := getFilesFromSFV(sfvPath)
+			gotFiles, err := getFilesFromSFV(releaseFS{}, sfvPath)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 				return
@@ -229,14 +229,20 @@ func TestRelease_PerformSFVCheck(t *testing.T) {
 
 			sfvPath := filepath.Join(tmpDir, testSFVName)
 
-			releaseService := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			releaseService, err := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			require.NoError(t, err)
 
 			rel, err := releaseService.Parse(tmpDir)
 			require.NoError(t, err)
 
-			gotResult, err := releaseService.performSFVCheck(rel, sfvPath, false)
+			report := &Report{}
+
+			gotResult, err := releaseService.performSFVCheck(rel, sfvPath, false, report)
+			require.NoError(t, err)
+
 			if tt.wantErr != nil {
-				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, gotResult)
+				assert.ErrorIs(t, report.err(ErrSfvValidationFailed), tt.wantErr)
 				return
 			}
 
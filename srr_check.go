@@ -21,12 +21,26 @@ var (
 )
 
 // CheckSRR validates the SRR integrity of a release using provided information and options.
+// All files are checked, on failure a *ReportError is returned that matches ErrSrrValidationFailed and the errors
+// of all findings.
 func (s *Service) CheckSRR(rel *Info, showProgress bool, fastCheck bool) error {
+	report, err := s.CheckSRRReport(rel, showProgress, fastCheck)
+	if err != nil {
+		return err
+	}
+
+	return report.err(ErrSrrValidationFailed)
+}
+
+// CheckSRRReport works like CheckSRR, but returns missing files, size and CRC mismatches as report. Release names
+// without a record on srrdb are added as warnings. An error is only returned if the check can't continue, e.g. if
+// nothing is found on srrdb or the context is canceled.
+func (s *Service) CheckSRRReport(rel *Info, showProgress bool, fastCheck bool) (*Report, error) {
 	startTime := time.Now()
 
 	useParallelRead, err := s.useParallelRead(rel.Root.FullPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var releases []string
@@ -35,9 +49,11 @@ func (s *Service) CheckSRR(rel *Info, showProgress bool, fastCheck bool) error {
 		releases = append(releases, f.Parent.Info.Name)
 	}
 
-	srrdbReleases, err := s.fetchSRRInformation(releases)
+	report := &Report{}
+
+	srrdbReleases, err := s.fetchSRRInformation(releases, report)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var totalSize int64
@@ -50,9 +66,9 @@ func (s *Service) CheckSRR(rel *Info, showProgress bool, fastCheck bool) error {
 	s.log.Info().Str("totalSize", utils.Bytes(totalSize)).Msg("starting srr check")
 
 	for _, srr := range srrdbReleases {
-		if err := s.verifySingleSRR(rel, srr, bar, useParallelRead, fastCheck); err != nil {
+		if err := s.verifySingleSRR(rel, srr, bar, useParallelRead, fastCheck, report); err != nil {
 			bar.Cancel()
-			return fmt.Errorf("verify srr %s: %w", srr.Name, err)
+			return nil, fmt.Errorf("verify srr %s: %w", srr.Name, err)
 		}
 	}
 
@@ -60,19 +76,20 @@ func (s *Service) CheckSRR(rel *Info, showProgress bool, fastCheck bool) error {
 
 	s.log.Info().Str("dur", time.Since(startTime).String()).Msg("checked srr")
 
-	return nil
+	return report, nil
 }
 
 // fetchSRRInformation retrieves SRR information for a list of release names from the SRR database.
-// It logs errors for individual releases that fail to retrieve and skips them.
+// It logs errors for individual releases that fail to retrieve, adds them to the report as warnings and skips them.
 // Returns an error if no SRR records are successfully retrieved.
-func (s *Service) fetchSRRInformation(releaseNames []string) ([]srrdb.Release, error) {
+func (s *Service) fetchSRRInformation(releaseNames []string, report *Report) ([]srrdb.Release, error) {
 	srrdbReleases := make([]srrdb.Release, 0, len(releaseNames))
 
 	for _, releaseName := range releaseNames {
 		srr, err := srrdb.GetInformation(releaseName)
 		if err != nil {
 			s.log.Error().Err(err).Str("release", releaseName).Msg("no srr record retrieved")
+			report.add(CodeNoSrr, SeverityWarning, "", fmt.Errorf("%s: %w", releaseName, err))
 			continue
 		}
 		srrdbReleases = append(srrdbReleases, srr)
@@ -86,15 +103,23 @@ func (s *Service) fetchSRRInformation(releaseNames []string) ([]srrdb.Release, e
 }
 
 // verifySingleSRR validates the integrity of a single SRR file by comparing its metadata with local files.
-func (s *Service) verifySingleSRR(rel *Info, srr srrdb.Release, bar progress.Progress, useParallelRead bool, fastCheck bool) error {
+// Every problem is added to the report and the check continues with the next file.
+func (s *Service) verifySingleSRR(rel *Info, srr srrdb.Release, bar progress.Progress, useParallelRead bool, fastCheck bool, report *Report) error {
+	passed := true
+
 	for _, fs := range srr.ArchivedFiles {
 		localFile, err := rel.Root.GetFile(fs.Name)
 		if err != nil {
-			return fmt.Errorf("get file: %w", err)
+			report.add(CodeMissingFile, SeverityError, fs.Name, fmt.Errorf("get file: %w", err))
+			passed = false
+			continue
 		}
 
 		if localFile.Info.Size != fs.Size {
-			return fmt.Errorf("%w: size mismatch", ErrSrrValidationFailed)
+			report.add(CodeSrrMismatch, SeverityError, localFile.FullPath,
+				fmt.Errorf("%w: size mismatch", ErrSrrValidationFailed))
+			passed = false
+			continue
 		}
 
 		if fastCheck {
@@ -104,10 +129,17 @@ func (s *Service) verifySingleSRR(rel *Info, srr srrdb.Release, bar progress.Pro
 
 		srrCRC, err := strconv.ParseUint(fs.CRC, 16, 32)
 		if err != nil {
-			return fmt.Errorf("parse crc: %w", err)
+			report.add(CodeInvalidSrr, SeverityError, localFile.FullPath, fmt.Errorf("parse crc: %w", err))
+			passed = false
+			continue
 		}
 
-		crcChecker := utils.NewCheckCRCBuilder(localFile.FullPath, uint32(srrCRC)).
+		crcBuilder, err := rel.files.crcBuilder(localFile.FullPath, uint32(srrCRC))
+		if err != nil {
+			return err
+		}
+
+		crcChecker := crcBuilder.
 			WithParallelRead(useParallelRead).
 			WithProgressBar(bar).
 			WithContext(s.ctx).
@@ -117,11 +149,17 @@ func (s *Service) verifySingleSRR(rel *Info, srr srrdb.Release, bar progress.Pro
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 				return err
 			}
-			return fmt.Errorf("%w: crc mismatch", ErrSrrValidationFailed)
+			report.add(CodeSrrMismatch, SeverityError, localFile.FullPath,
+				fmt.Errorf("%w: crc mismatch", ErrSrrValidationFailed))
+			passed = false
 		}
 	}
 
-	s.log.Debug().Str("srr", srr.Name).Msg("check passed")
+	if passed {
+		s.log.Debug().Str("srr", srr.Name).Msg("check passed")
+	} else {
+		s.log.Error().Str("srr", srr.Name).Msg("check failed")
+	}
 
 	return nil
 }
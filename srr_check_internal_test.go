@@ -138,13 +138,17 @@ func TestRelease_VerifySingleSRR(t *testing.T) {
 			tempDir := t.TempDir()
 			setupTestDir(t, tempDir, tt.testFiles)
 
-			releaseService := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			releaseService, err := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			require.NoError(t, err)
 
 			rel, err := releaseService.Parse(tempDir)
 			require.NoError(t, err)
 
-			gotErr := releaseService.verifySingleSRR(rel, tt.inputSRR, &progress.NoOpProgressBar{}, false, tt.fastCheck)
-			assert.ErrorIs(t, gotErr, tt.wantErr)
+			report := &Report{}
+
+			gotErr := releaseService.verifySingleSRR(rel, tt.inputSRR, &progress.NoOpProgressBar{}, false, tt.fastCheck, report)
+			require.NoError(t, gotErr)
+			assert.ErrorIs(t, report.err(ErrSrrValidationFailed), tt.wantErr)
 		})
 	}
 
@@ -156,14 +160,15 @@ func TestRelease_VerifySingleSRR(t *testing.T) {
 		defer cancel()
 
 		// check if cancellation works
-		releaseService := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).WithContext(ctx).Build()
+		releaseService, err := NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).WithContext(ctx).Build()
+		require.NoError(t, err)
 
 		rel, err := releaseService.Parse(tempDir)
 		require.NoError(t, err)
 
 		cancel()
 
-		gotErr := releaseService.verifySingleSRR(rel, validTest.inputSRR, &progress.NoOpProgressBar{}, false, false)
+		gotErr := releaseService.verifySingleSRR(rel, validTest.inputSRR, &progress.NoOpProgressBar{}, false, false, &Report{})
 		assert.ErrorIs(t, gotErr, context.Canceled)
 	})
 }
@@ -0,0 +1,160 @@
+package release
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/f4n4t/go-dtree"
+)
+
+// SymlinkPolicy defines how Parse handles symbolic links inside the release.
+// Broken symlinks and special files (sockets, named pipes and devices) are always added to the forbidden files.
+type SymlinkPolicy int
+
+const (
+	// SymlinkRecord keeps symlinks in the tree as they are without following them and records them in Info.Symlinks.
+	SymlinkRecord SymlinkPolicy = iota
+	// SymlinkFollow follows symlinks to files and folders, folders that link to one of their parents are forbidden.
+	SymlinkFollow
+	// SymlinkReject adds every symlink to the forbidden files.
+	SymlinkReject
+)
+
+// maxFollowDepth is the folder depth at which following symlinks stops. It is only a fallback for file systems
+// whose file infos can't be compared with os.SameFile.
+const maxFollowDepth = 64
+
+// Symlink is a symbolic link found in the release.
+type Symlink struct {
+	// Path is the FullPath of the link.
+	Path string `json:"path"`
+	// Target is the target of the link as it is stored, empty if the file system can't read links.
+	Target string `json:"target"`
+}
+
+// namedFileInfo is the file info of a symlink target with the name of the link.
+type namedFileInfo struct {
+	fs.FileInfo
+	name string
+}
+
+// Name returns the name of the link.
+func (fi namedFileInfo) Name() string {
+	return fi.name
+}
+
+// isSpecialFile checks if the mode is a socket, named pipe, device or another irregular file.
+func isSpecialFile(mode fs.FileMode) bool {
+	return mode&(fs.ModeSocket|fs.ModeNamedPipe|fs.ModeDevice|fs.ModeCharDevice|fs.ModeIrregular) != 0
+}
+
+// processSpecialFile adds a special file to the forbidden files, it is not added to the tree because reading it
+// could block.
+func (s *Service) processSpecialFile(info *Info, fullPath string, fileInfo *dtree.FileInfo, ignore *IgnoreMatcher) error {
+	skip, err := s.checkIgnoreList(info, fullPath, fileInfo, ignore)
+	if err != nil || skip != skipNothing {
+		return err
+	}
+
+	s.log.Error().Str("name", fileInfo.Name).Err(ErrSpecialFile).Msg("")
+	info.addForbiddenFile(CodeSpecialFile, fullPath, fileInfo, ErrSpecialFile)
+
+	return nil
+}
+
+// processSymlink handles a symlink found while walking the release according to the symlink policy.
+// Symlinks to folders are walked with the given walk function if they are followed.
+func (s *Service) processSymlink(info *Info, fsPath string, linkInfo fs.FileInfo, ignore *IgnoreMatcher, walk fs.WalkDirFunc) error {
+	fullPath := info.files.fullPath(fsPath)
+	nodeInfo := dtree.FileInfoFromInterface(linkInfo)
+
+	skip, err := s.checkIgnoreList(info, fullPath, nodeInfo, ignore)
+	if err != nil || skip != skipNothing {
+		return err
+	}
+
+	target, _ := fs.ReadLink(info.files.fsys, fsPath)
+
+	targetInfo, err := fs.Stat(info.files.fsys, fsPath)
+	if err != nil {
+		linkErr := fmt.Errorf("%w: %s", ErrBrokenSymlink, target)
+		s.log.Error().Str("name", linkInfo.Name()).Err(linkErr).Msg("")
+		info.addForbiddenFile(CodeBrokenSymlink, fullPath, nodeInfo, linkErr)
+		return nil
+	}
+
+	if isSpecialFile(targetInfo.Mode()) {
+		s.log.Error().Str("name", linkInfo.Name()).Err(ErrSpecialFile).Msg("")
+		info.addForbiddenFile(CodeSpecialFile, fullPath, nodeInfo, ErrSpecialFile)
+		return nil
+	}
+
+	switch s.symlinkPolicy {
+	case SymlinkReject:
+		s.log.Error().Str("name", linkInfo.Name()).Err(ErrForbiddenSymlink).Msg("")
+		info.addForbiddenFile(CodeSymlink, fullPath, nodeInfo, ErrForbiddenSymlink)
+		return nil
+
+	case SymlinkFollow:
+		info.Symlinks = append(info.Symlinks, Symlink{Path: fullPath, Target: target})
+
+		followedInfo := namedFileInfo{FileInfo: targetInfo, name: linkInfo.Name()}
+
+		if !targetInfo.IsDir() {
+			return s.processPath(info, fullPath, dtree.FileInfoFromInterface(followedInfo), ignore)
+		}
+
+		if isSymlinkLoop(info.files.fsys, fsPath, targetInfo) {
+			linkErr := fmt.Errorf("%w: %s", ErrSymlinkLoop, target)
+			s.log.Error().Str("name", linkInfo.Name()).Err(linkErr).Msg("")
+			info.addForbiddenFile(CodeSymlinkLoop, fullPath, nodeInfo, linkErr)
+			return nil
+		}
+
+		s.log.Debug().Str("name", linkInfo.Name()).Str("target", target).Msg("following symlink")
+
+		// the walk starts with the target, so the name of the link is used for the root entry
+		return fs.WalkDir(info.files.fsys, fsPath, func(walkPath string, entry fs.DirEntry, walkErr error) error {
+			if walkPath == fsPath && walkErr == nil {
+				entry = fs.FileInfoToDirEntry(followedInfo)
+			}
+			return walk(walkPath, entry, walkErr)
+		})
+
+	default:
+		info.Symlinks = append(info.Symlinks, Symlink{Path: fullPath, Target: target})
+		return s.processPath(info, fullPath, nodeInfo, ignore)
+	}
+}
+
+// viaSymlink checks if the FullPath is a symlink of the release or lies in a followed symlink folder.
+func (i *Info) viaSymlink(fullPath string) bool {
+	for _, link := range i.Symlinks {
+		rest, found := strings.CutPrefix(fullPath, link.Path)
+		if found && (rest == "" || rest[0] == '/' || rest[0] == filepath.Separator) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSymlinkLoop checks if the target of the symlink is one of the folders the link is in.
+func isSymlinkLoop(fsys fs.FS, fsPath string, targetInfo fs.FileInfo) bool {
+	if strings.Count(fsPath, "/") >= maxFollowDepth {
+		return true
+	}
+
+	for dir := path.Dir(fsPath); ; dir = path.Dir(dir) {
+		if dirInfo, err := fs.Stat(fsys, dir); err == nil && os.SameFile(dirInfo, targetInfo) {
+			return true
+		}
+
+		if dir == "." || dir == "/" {
+			return false
+		}
+	}
+}
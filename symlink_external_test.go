@@ -0,0 +1,104 @@
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func symlink(t *testing.T, target, link string) {
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+}
+
+func TestParse_SymlinkPolicy(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Test.Release-Group/test.mkv":        []byte("content\n"),
+		"Test.Release-Group/Subs/test.idx":   []byte("idx\n"),
+		"Outside/extra.nfo":                  []byte("imdb.com/title/tt0123456\n"),
+		"Outside/Proof/test-proof.jpg":       []byte("jpg\n"),
+		"Test.Release-Group/Sample/test.txt": []byte("txt\n"),
+	})
+
+	releaseDir := filepath.Join(tmpDir, "Test.Release-Group")
+	symlink(t, filepath.Join(tmpDir, "Outside", "extra.nfo"), filepath.Join(releaseDir, "test.nfo"))
+	symlink(t, filepath.Join(tmpDir, "Outside", "Proof"), filepath.Join(releaseDir, "Proof"))
+
+	newService := func(policy release.SymlinkPolicy) *release.Service {
+		releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).
+			WithSymlinkPolicy(policy).Build()
+		require.NoError(t, err)
+		return releaseService
+	}
+
+	t.Run("record", func(t *testing.T) {
+		rel, err := newService(release.SymlinkRecord).Parse(releaseDir)
+		require.NoError(t, err)
+
+		assert.Len(t, rel.Symlinks, 2)
+		assert.False(t, rel.HasExtensions(".jpg"), "symlinked folder must not be walked")
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		rel, err := newService(release.SymlinkFollow).Parse(releaseDir)
+		require.NoError(t, err)
+
+		assert.Len(t, rel.Symlinks, 2)
+		assert.True(t, rel.HasExtensions(".jpg", ".nfo"))
+		assert.Equal(t, 123456, rel.ImdbID)
+
+		node, err := rel.Root.GetFile("test-proof.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, "Proof", node.Parent.Info.Name)
+		assert.Equal(t, int64(4), node.Info.Size)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		rel, err := newService(release.SymlinkReject).Parse(releaseDir)
+		assert.ErrorIs(t, err, release.ErrForbiddenSymlink)
+		require.NotNil(t, rel)
+
+		assert.ElementsMatch(t, []string{"test.nfo", "Proof"}, rel.ForbiddenFiles.Names())
+		assert.Len(t, rel.Report.ByCode(release.CodeSymlink), 2)
+	})
+
+	t.Run("broken symlink", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		setupTestDir(t, tmpDir, map[string][]byte{
+			"Test.Release-Group/test.mkv": []byte("content\n"),
+		})
+		symlink(t, "missing.nfo", filepath.Join(tmpDir, "Test.Release-Group", "test.nfo"))
+
+		for _, policy := range []release.SymlinkPolicy{release.SymlinkRecord, release.SymlinkFollow} {
+			rel, err := newService(policy).Parse(filepath.Join(tmpDir, "Test.Release-Group"))
+			assert.ErrorIs(t, err, release.ErrBrokenSymlink)
+			require.NotNil(t, rel)
+			assert.Equal(t, []string{"test.nfo"}, rel.ForbiddenFiles.Names())
+		}
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		setupTestDir(t, tmpDir, map[string][]byte{
+			"Test.Release-Group/test.mkv":     []byte("content\n"),
+			"Test.Release-Group/Sub/test.txt": []byte("text\n"),
+		})
+		symlink(t, "..", filepath.Join(tmpDir, "Test.Release-Group", "Sub", "Loop"))
+
+		rel, err := newService(release.SymlinkFollow).Parse(filepath.Join(tmpDir, "Test.Release-Group"))
+		assert.ErrorIs(t, err, release.ErrSymlinkLoop)
+		require.NotNil(t, rel)
+		assert.Equal(t, []string{"Loop"}, rel.ForbiddenFiles.Names())
+		assert.Equal(t, 1, rel.Extensions[".txt"])
+	})
+}
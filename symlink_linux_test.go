@@ -0,0 +1,36 @@
+package release_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestParse_SpecialFiles(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	tmpDir := t.TempDir()
+	setupTestDir(t, tmpDir, map[string][]byte{
+		"Test.Release-Group/test.mkv": []byte("content\n"),
+	})
+
+	// reading the pipe would block the parse
+	require.NoError(t, unix.Mkfifo(filepath.Join(tmpDir, "Test.Release-Group", "test.nfo"), 0666))
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	rel, err := releaseService.Parse(filepath.Join(tmpDir, "Test.Release-Group"))
+	assert.ErrorIs(t, err, release.ErrSpecialFile)
+	require.NotNil(t, rel)
+
+	assert.Equal(t, []string{"test.nfo"}, rel.ForbiddenFiles.Names())
+	assert.Nil(t, rel.NFO)
+	assert.False(t, rel.HasExtensions(".nfo"))
+}
@@ -0,0 +1,174 @@
+package release
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ScanType is the scan type of the video.
+type ScanType string
+
+const (
+	Progressive ScanType = "progressive"
+	Interlaced  ScanType = "interlaced"
+)
+
+// HDRFormat is a high dynamic range format of the video.
+type HDRFormat string
+
+const (
+	HDR10       HDRFormat = "hdr10"
+	HDR10Plus   HDRFormat = "hdr10+"
+	DolbyVision HDRFormat = "dv"
+	HLG         HDRFormat = "hlg"
+)
+
+// VideoFormat describes the video of a release, parsed from the name (see ParseVideoFormat) and the video track
+// of the mediainfo (see MediaInfo.VideoFormat).
+type VideoFormat struct {
+	// Resolution is the resolution of the video, SD if the name has no resolution tag.
+	Resolution Resolution `json:"resolution"`
+	// ScanType is progressive or interlaced, empty if unknown.
+	ScanType ScanType `json:"scan_type,omitempty"`
+	// HDR holds all HDR formats, e.g. DolbyVision and HDR10 for a Dolby Vision release with an HDR10 fallback.
+	HDR []HDRFormat `json:"hdr,omitempty"`
+	// BitDepth is the bit depth of the video, e.g. 10, 0 if unknown.
+	BitDepth int `json:"bit_depth,omitempty"`
+	// ThreeD is true for 3D videos, e.g. "3D.HSBS" or a mediainfo track with two views.
+	ThreeD bool `json:"3d,omitempty"`
+}
+
+// videoFormatRegexes holds the patterns of ParseVideoFormat, the resolution and HDR tags use nameRegexes.
+var videoFormatRegexes = struct {
+	bitDepth, threeD *regexp.Regexp
+}{
+	// the bit depth also accepts brackets as separators, e.g. "[1080p Hi10P]" of fansub names
+	bitDepth: regexp.MustCompile(`(?i)(?:^|[._ (\[-])((8|10|12)[._-]?bits?|hi(10)p?)(?:[._ )\]-]|$)`),
+	threeD:   tokenPattern(`3d|h-?sbs|sbs|h-?ou|half[._-]?(?:sbs|ou|tab)|mvc`),
+}
+
+// ParseVideoFormat parses the video format from the release name, e.g. "2160p.DV.HDR10.10bit".
+func ParseVideoFormat(name string) VideoFormat {
+	vf := VideoFormat{Resolution: ParseResolution(name)}
+
+	if tokens := findTokens(nameRegexes.resolution, name); len(tokens) > 0 {
+		vf.ScanType = Progressive
+		if value := tokens[len(tokens)-1].Value; strings.HasSuffix(strings.ToLower(value), "i") {
+			vf.ScanType = Interlaced
+		}
+	}
+
+	if nameRegexes.dolbyVision.MatchString(name) {
+		vf.HDR = appendHDR(vf.HDR, DolbyVision)
+	}
+
+	for _, token := range findTokens(nameRegexes.hdr, name) {
+		switch value := strings.ToLower(token.Value); {
+		case strings.HasSuffix(value, "+") || strings.HasSuffix(value, "plus"):
+			vf.HDR = appendHDR(vf.HDR, HDR10Plus)
+		case value == "hlg":
+			vf.HDR = appendHDR(vf.HDR, HLG)
+		default:
+			vf.HDR = appendHDR(vf.HDR, HDR10)
+		}
+	}
+
+	if m := videoFormatRegexes.bitDepth.FindStringSubmatch(name); m != nil {
+		vf.BitDepth, _ = strconv.Atoi(m[2] + m[3])
+	}
+
+	vf.ThreeD = videoFormatRegexes.threeD.MatchString(name)
+
+	return vf
+}
+
+// appendHDR adds the format if it's not in the list yet.
+func appendHDR(formats []HDRFormat, format HDRFormat) []HDRFormat {
+	if slices.Contains(formats, format) {
+		return formats
+	}
+	return append(formats, format)
+}
+
+// HasHDR checks if the video has any of the given HDR formats, or any HDR format at all if none is given.
+func (vf VideoFormat) HasHDR(formats ...HDRFormat) bool {
+	if len(formats) == 0 {
+		return len(vf.HDR) > 0
+	}
+
+	return slices.ContainsFunc(formats, func(format HDRFormat) bool {
+		return slices.Contains(vf.HDR, format)
+	})
+}
+
+// Merge returns the format with all known values of other, e.g. of the mediainfo, which is more reliable than
+// the name. The HDR formats of both are kept and the video is 3D if either one is.
+func (vf VideoFormat) Merge(other VideoFormat) VideoFormat {
+	merged := vf
+	merged.HDR = slices.Clone(vf.HDR)
+
+	if other.Resolution != "" {
+		merged.Resolution = other.Resolution
+	}
+	if other.ScanType != "" {
+		merged.ScanType = other.ScanType
+	}
+	if other.BitDepth != 0 {
+		merged.BitDepth = other.BitDepth
+	}
+	for _, format := range other.HDR {
+		merged.HDR = appendHDR(merged.HDR, format)
+	}
+
+	merged.ThreeD = vf.ThreeD || other.ThreeD
+
+	return merged
+}
+
+// VideoFormat returns the format of the first video track, the resolution is the nearest standard resolution
+// (see GetNearestResolution). An empty format is returned if there is no video track.
+func (m *MediaInfo) VideoFormat() VideoFormat {
+	index := slices.IndexFunc(m.Media.Tracks, func(track MediaInfoTrack) bool {
+		return track.Type == string(Video)
+	})
+	if index < 0 {
+		return VideoFormat{}
+	}
+
+	track := m.Media.Tracks[index]
+
+	vf := VideoFormat{Resolution: m.GetNearestResolution()}
+
+	switch strings.ToLower(track.ScanType) {
+	case "progressive":
+		vf.ScanType = Progressive
+	case "interlaced", "mbaff", "paff":
+		vf.ScanType = Interlaced
+	}
+
+	// e.g. "Dolby Vision / SMPTE ST 2086" with the compatibility "HDR10" or "SMPTE ST 2094 App 4" for HDR10+
+	hdrFormat := strings.ToLower(track.HDRFormat + " / " + track.HDRFormatCompatibility)
+
+	if strings.Contains(hdrFormat, "dolby vision") {
+		vf.HDR = appendHDR(vf.HDR, DolbyVision)
+	}
+	if strings.Contains(hdrFormat, "2094") || strings.Contains(hdrFormat, "hdr10+") {
+		vf.HDR = appendHDR(vf.HDR, HDR10Plus)
+	}
+	if strings.Contains(hdrFormat, "2086") || strings.Contains(strings.ReplaceAll(hdrFormat, "hdr10+", ""), "hdr10") {
+		vf.HDR = appendHDR(vf.HDR, HDR10)
+	}
+	if transfer := strings.ToLower(track.TransferCharacteristics); transfer == "hlg" || transfer == "arib std-b67" {
+		vf.HDR = appendHDR(vf.HDR, HLG)
+	}
+
+	vf.BitDepth, _ = strconv.Atoi(track.BitDepth)
+
+	if views, err := strconv.Atoi(track.MultiViewCount); err == nil && views > 1 {
+		vf.ThreeD = true
+	}
+
+	return vf
+}
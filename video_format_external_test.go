@@ -0,0 +1,95 @@
+package release_test
+
+import (
+	"testing"
+
+	"github.com/f4n4t/go-release"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVideoFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected release.VideoFormat
+	}{
+		{"No Tags", "Movie.Title.2023.DVDRip.x264-GROUP", release.VideoFormat{Resolution: release.SD}},
+		{
+			"Progressive", "Movie.Title.2023.1080p.BluRay.x264-GROUP",
+			release.VideoFormat{Resolution: release.FHD, ScanType: release.Progressive},
+		},
+		{
+			"Interlaced", "Movie.Title.2023.1080i.HDTV.x264-GROUP",
+			release.VideoFormat{Resolution: release.FHD, ScanType: release.Interlaced},
+		},
+		{
+			"Dolby Vision and HDR10", "Movie.Title.2023.2160p.UHD.BluRay.DV.HDR10.10bit.x265-GROUP",
+			release.VideoFormat{
+				Resolution: release.UHD, ScanType: release.Progressive,
+				HDR: []release.HDRFormat{release.DolbyVision, release.HDR10}, BitDepth: 10,
+			},
+		},
+		{
+			"HDR10Plus", "Movie.Title.2023.2160p.WEB.HDR10Plus.h265-GROUP",
+			release.VideoFormat{Resolution: release.UHD, ScanType: release.Progressive, HDR: []release.HDRFormat{release.HDR10Plus}},
+		},
+		{
+			"HLG", "Show.S01E01.2160p.HLG.WEB.h265-GROUP",
+			release.VideoFormat{Resolution: release.UHD, ScanType: release.Progressive, HDR: []release.HDRFormat{release.HLG}},
+		},
+		{
+			"3D", "Movie.Title.2023.3D.HSBS.1080p.BluRay.x264-GROUP",
+			release.VideoFormat{Resolution: release.FHD, ScanType: release.Progressive, ThreeD: true},
+		},
+		{
+			"Hi10P", "[Group] Title - 01 [720p Hi10P].mkv",
+			release.VideoFormat{Resolution: release.HD, ScanType: release.Progressive, BitDepth: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, release.ParseVideoFormat(tt.filename), "Filename: %s", tt.filename)
+		})
+	}
+}
+
+func TestMediaInfo_VideoFormat(t *testing.T) {
+	mediaInfo := &release.MediaInfo{
+		Media: release.Media{
+			Tracks: []release.MediaInfoTrack{
+				{Type: string(release.General)},
+				{
+					Type: string(release.Video), Width: "3840", Height: "2160", ScanType: "Progressive", BitDepth: "10",
+					HDRFormat: "Dolby Vision / SMPTE ST 2086", HDRFormatCompatibility: "Blu-ray / HDR10",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, release.VideoFormat{
+		Resolution: release.UHD, ScanType: release.Progressive,
+		HDR: []release.HDRFormat{release.DolbyVision, release.HDR10}, BitDepth: 10,
+	}, mediaInfo.VideoFormat())
+
+	assert.Equal(t, release.VideoFormat{}, (&release.MediaInfo{}).VideoFormat())
+}
+
+func TestVideoFormat_Merge(t *testing.T) {
+	name := release.ParseVideoFormat("Movie.Title.2023.UHD.BluRay.DV.3D.x265-GROUP")
+	mediaInfo := release.VideoFormat{
+		Resolution: release.UHD8K, ScanType: release.Progressive, HDR: []release.HDRFormat{release.HDR10}, BitDepth: 10,
+	}
+
+	merged := name.Merge(mediaInfo)
+	assert.Equal(t, release.VideoFormat{
+		Resolution: release.UHD8K, ScanType: release.Progressive,
+		HDR: []release.HDRFormat{release.DolbyVision, release.HDR10}, BitDepth: 10, ThreeD: true,
+	}, merged)
+	assert.True(t, merged.HasHDR())
+	assert.True(t, merged.HasHDR(release.HLG, release.HDR10))
+	assert.False(t, merged.HasHDR(release.HDR10Plus))
+
+	// merging does not change the receiver
+	assert.Equal(t, []release.HDRFormat{release.DolbyVision}, name.HDR)
+}
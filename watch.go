@@ -0,0 +1,292 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultQuietPeriod is the time without writes after which a release is checked for completeness.
+const defaultQuietPeriod = 30 * time.Second
+
+// WatchCheck is a check that runs on every complete release of Watch.
+type WatchCheck func(s *Service, rel *Info) error
+
+// WatchCheckSFV runs CheckSFV if the release contains sfv files.
+func WatchCheckSFV(s *Service, rel *Info) error {
+	if rel.SfvCount == 0 {
+		return nil
+	}
+	return s.CheckSFV(rel, false)
+}
+
+// WatchCheckZip runs CheckZip if the release contains zip files.
+func WatchCheckZip(s *Service, rel *Info) error {
+	if !rel.HasExtensions(".zip") {
+		return nil
+	}
+	return s.CheckZip(rel, false)
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// QuietPeriod is the time without writes before a release is checked for completeness, defaults to 30 seconds.
+	QuietPeriod time.Duration
+	// Checks run in the given order after the release was parsed.
+	Checks []WatchCheck
+	// Ignore holds the ignore patterns passed to Parse.
+	Ignore []string
+}
+
+// WatchEvent is published for every release that looks complete.
+type WatchEvent struct {
+	// Root is the path of the release.
+	Root string
+	// Info is the parsed release, it can be set together with Err (e.g. ErrForbiddenFiles).
+	Info *Info
+	// Err is the error returned by Parse.
+	Err error
+	// CheckErr holds the joined errors of the checks, the checks only run if Info is set.
+	CheckErr error
+}
+
+// Watch monitors the incoming directory and tracks every release folder (or single file) as files arrive.
+// Releases that already exist when the watch starts are tracked as well.
+// A release is complete if every sfv entry is present with a plausible size, the zip count matches the
+// count of the .diz and no writes have happened for the quiet period. It is then parsed, the checks are
+// run and the result is published. Later writes to the same release publish it again.
+// A sfv holds no file sizes, so a plausible size only means that no entry is empty and that at most one
+// archive volume differs in size from the others. A file with the right name but the wrong size is only
+// detected by the checks, e.g. WatchCheckSFV.
+// On linux inotify is used, other systems poll the directory. The channel is closed after ctx is canceled.
+func (s *Service) Watch(ctx context.Context, dir string, opts WatchOptions) (<-chan WatchEvent, error) {
+	if opts.QuietPeriod <= 0 {
+		opts.QuietPeriod = defaultQuietPeriod
+	}
+
+	changes, err := watchDir(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("watch directory: %w", err)
+	}
+
+	// the existing releases are checked after the quiet period like new ones
+	existing, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+
+	worker := *s
+	worker.ctx = ctx
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		var (
+			wg      sync.WaitGroup
+			pending = make(map[string]*watchedRelease)
+			ticker  = time.NewTicker(max(opts.QuietPeriod/4, 10*time.Millisecond))
+		)
+
+		defer ticker.Stop()
+		defer wg.Wait()
+
+		for _, entry := range existing {
+			pending[entry.Name()] = &watchedRelease{lastWrite: time.Now()}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case name, ok := <-changes:
+				if !ok {
+					return
+				}
+				pending[name] = &watchedRelease{lastWrite: time.Now()}
+
+			case now := <-ticker.C:
+				for name, rel := range pending {
+					if rel.checked || now.Sub(rel.lastWrite) < opts.QuietPeriod {
+						continue
+					}
+
+					// wait for the next write if the release is not complete yet
+					rel.checked = true
+
+					root := filepath.Join(dir, name)
+
+					// the release was deleted or moved away
+					if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+						delete(pending, name)
+						continue
+					}
+
+					if complete, err := releaseComplete(root); !complete {
+						s.log.Debug().Err(err).Str("release", name).Msg("release not complete")
+						continue
+					}
+
+					delete(pending, name)
+
+					wg.Go(func() {
+						event := worker.processWatchedRelease(root, opts)
+
+						select {
+						case events <- event:
+						case <-ctx.Done():
+						}
+					})
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// watchedRelease is a release folder with write activity.
+type watchedRelease struct {
+	lastWrite time.Time
+	// checked is set after the completeness check and reset with the next write.
+	checked bool
+}
+
+// processWatchedRelease parses a complete release and runs all checks.
+func (s *Service) processWatchedRelease(root string, opts WatchOptions) WatchEvent {
+	event := WatchEvent{Root: root}
+
+	event.Info, event.Err = s.Parse(root, opts.Ignore...)
+	if event.Info == nil {
+		return event
+	}
+
+	var checkErrs []error
+	for _, check := range opts.Checks {
+		if err := check(s, event.Info); err != nil {
+			checkErrs = append(checkErrs, err)
+		}
+	}
+
+	event.CheckErr = errors.Join(checkErrs...)
+
+	s.log.Info().Str("release", event.Info.Name).Msg("release complete")
+
+	return event
+}
+
+// releaseComplete checks if every sfv entry is present with a plausible size and if the count of the zip files
+// matches the count in the .diz. The error explains why a release is not complete.
+func releaseComplete(root string) (bool, error) {
+	rootInfo, err := os.Stat(root)
+	if err != nil {
+		return false, err
+	}
+
+	if !rootInfo.IsDir() {
+		return true, nil
+	}
+
+	var sfvPaths []string
+	zipPaths := make(map[string][]string)
+
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch filepath.Ext(entry.Name()) {
+		case ".sfv":
+			sfvPaths = append(sfvPaths, path)
+		case ".zip":
+			zipPaths[filepath.Dir(path)] = append(zipPaths[filepath.Dir(path)], path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, sfvPath := range sfvPaths {
+		if err := sfvComplete(sfvPath); err != nil {
+			return false, err
+		}
+	}
+
+	for _, files := range zipPaths {
+		if err := zipsComplete(files); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// sfvComplete checks that every entry of the sfv exists and is not empty. As archive volumes share
+// one size, only a single volume (the last one) is allowed to have a different size.
+func sfvComplete(sfvPath string) error {
+	entries, err := getFilesFromSFV(releaseFS{}, sfvPath)
+	if err != nil {
+		return err
+	}
+
+	var volumeSizes []int64
+
+	for _, entry := range entries {
+		if entry.size == 0 {
+			return fmt.Errorf("%w: %s", ErrEmptyFile, entry.name)
+		}
+
+		if rarFilesPattern.MatchString(filepath.Ext(entry.name)) {
+			volumeSizes = append(volumeSizes, entry.size)
+		}
+	}
+
+	if len(volumeSizes) < 2 {
+		return nil
+	}
+
+	volumeSize := slices.Max(volumeSizes)
+
+	differentSizes := 0
+	for _, size := range volumeSizes {
+		if size != volumeSize {
+			differentSizes++
+		}
+	}
+
+	if differentSizes > 1 {
+		return fmt.Errorf("%d archive volumes are smaller than %d bytes", differentSizes, volumeSize)
+	}
+
+	return nil
+}
+
+// zipsComplete checks that the count of the zip files in one folder matches the count of the .diz.
+func zipsComplete(files []string) error {
+	zipReader, closeZip, err := releaseFS{}.openZip(files[0])
+	if err != nil {
+		return fmt.Errorf("read zip file: %w", err)
+	}
+
+	archive, _, err := processZipContents(zipReader, false)
+	_ = closeZip()
+	if err != nil {
+		return err
+	}
+
+	if archive.total != len(files) {
+		return fmt.Errorf("expected %d zip files, got %d", archive.total, len(files))
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package release_test
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/f4n4t/go-release"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Watch(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	incoming := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	events, err := releaseService.Watch(ctx, incoming, release.WatchOptions{
+		QuietPeriod: 100 * time.Millisecond,
+		Checks:      []release.WatchCheck{release.WatchCheckSFV, release.WatchCheckZip},
+	})
+	require.NoError(t, err)
+
+	rar, r00 := []byte("first volume"), []byte("last")
+	releaseDir := filepath.Join(incoming, "Movie.2020.German.1080p.BluRay.x264-Group")
+
+	// the sfv arrives first, the last volume is still missing
+	setupTestDir(t, incoming, map[string][]byte{
+		"Movie.2020.German.1080p.BluRay.x264-Group/group.sfv": fmt.Appendf(nil, "group.rar %08x\ngroup.r00 %08x\n",
+			crc32.ChecksumIEEE(rar), crc32.ChecksumIEEE(r00)),
+		"Movie.2020.German.1080p.BluRay.x264-Group/group.rar": rar,
+	})
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event for incomplete release: %s", event.Root)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(releaseDir, "group.r00"), r00, 0644))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, releaseDir, event.Root)
+		assert.NoError(t, event.Err)
+		assert.NoError(t, event.CheckErr)
+		require.NotNil(t, event.Info)
+		assert.Equal(t, "Movie.2020.German.1080p.BluRay.x264-Group", event.Info.Name)
+		assert.Equal(t, 2, event.Info.ArchiveCount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for complete release")
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel not closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestService_Watch_ExistingRelease(t *testing.T) {
+	// disable logger
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	incoming := t.TempDir()
+
+	content := []byte("content")
+	setupTestDir(t, incoming, map[string][]byte{
+		"Movie.2020.German.1080p.BluRay.x264-Group/group.sfv": fmt.Appendf(nil, "group.mkv %08x\n", crc32.ChecksumIEEE(content)),
+		"Movie.2020.German.1080p.BluRay.x264-Group/group.mkv": content,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+	require.NoError(t, err)
+
+	events, err := releaseService.Watch(ctx, incoming, release.WatchOptions{QuietPeriod: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, filepath.Join(incoming, "Movie.2020.German.1080p.BluRay.x264-Group"), event.Root)
+		assert.NoError(t, event.Err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for existing release")
+	}
+}
+
+func TestService_Watch_MissingDir(t *testing.T) {
+	releaseService, err := release.NewServiceBuilder().Build()
+	require.NoError(t, err)
+
+	_, err = releaseService.Watch(context.Background(), filepath.Join(t.TempDir(), "missing"), release.WatchOptions{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,32 @@
+package release
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestInotifyWatcher_handleEvent_Overflow(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "First-Group", "Subs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Second-Group.mkv"), []byte("content"), 0644))
+
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC)
+	require.NoError(t, err)
+	defer unix.Close(fd)
+
+	w := &inotifyWatcher{fd: fd, dir: dir, watches: make(map[int]string)}
+
+	changed := make(map[string]struct{})
+	w.handleEvent(&unix.InotifyEvent{Wd: -1, Mask: unix.IN_Q_OVERFLOW}, "", changed)
+
+	assert.Equal(t, map[string]struct{}{"First-Group": {}, "Second-Group.mkv": {}}, changed)
+	assert.ElementsMatch(t, []string{dir, filepath.Join(dir, "First-Group"), filepath.Join(dir, "First-Group", "Subs")},
+		slices.Collect(maps.Values(w.watches)))
+}
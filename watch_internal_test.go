@@ -0,0 +1,123 @@
+package release
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withSFV moves the files into the root folder and adds a release.sfv with the checksums of all files.
+// Missing files are only added to the sfv.
+func withSFV(root string, files map[string][]byte, missing ...string) map[string][]byte {
+	var sfv strings.Builder
+
+	testFiles := make(map[string][]byte, len(files)+1)
+	for name, content := range files {
+		fmt.Fprintf(&sfv, "%s %08x\n", name, crc32.ChecksumIEEE(content))
+		testFiles[filepath.Join(root, name)] = content
+	}
+
+	for _, name := range missing {
+		fmt.Fprintf(&sfv, "%s %08x\n", name, 0)
+	}
+
+	testFiles[filepath.Join(root, "release.sfv")] = []byte(sfv.String())
+
+	return testFiles
+}
+
+func TestReleaseComplete(t *testing.T) {
+	tests := []struct {
+		desc        string
+		root        string
+		testFiles   map[string][]byte
+		expected    bool
+		expectedErr error
+	}{
+		{
+			desc: "complete rar release",
+			root: "Release-Group",
+			testFiles: withSFV("Release-Group", map[string][]byte{
+				"group.rar": []byte("aaaa"),
+				"group.r00": []byte("bbbb"),
+				"group.r01": []byte("cc"),
+			}),
+			expected: true,
+		},
+		{
+			desc:        "missing sfv entry",
+			root:        "Release-Group",
+			testFiles:   withSFV("Release-Group", map[string][]byte{"group.rar": []byte("aaaa")}, "group.r00"),
+			expectedErr: os.ErrNotExist,
+		},
+		{
+			desc: "empty sfv entry",
+			root: "Release-Group",
+			testFiles: withSFV("Release-Group", map[string][]byte{
+				"group.rar": []byte("aaaa"),
+				"group.r00": {},
+			}),
+			expectedErr: ErrEmptyFile,
+		},
+		{
+			desc: "incomplete archive volumes",
+			root: "Release-Group",
+			testFiles: withSFV("Release-Group", map[string][]byte{
+				"group.rar": []byte("aaaa"),
+				"group.r00": []byte("bb"),
+				"group.r01": []byte("c"),
+			}),
+		},
+		{
+			desc:      "no sfv and no zip",
+			root:      "Release-Group",
+			testFiles: map[string][]byte{"Release-Group/movie.mkv": []byte("movie")},
+			expected:  true,
+		},
+		{
+			desc:      "single file",
+			root:      "Release-Group.mkv",
+			testFiles: map[string][]byte{"Release-Group.mkv": []byte("single")},
+			expected:  true,
+		},
+		{
+			desc:        "missing release",
+			root:        "Release-Group",
+			testFiles:   map[string][]byte{},
+			expectedErr: os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tempDir := t.TempDir()
+
+			setupTestDir(t, tempDir, tt.testFiles)
+
+			got, gotErr := releaseComplete(filepath.Join(tempDir, tt.root))
+			assert.Equal(t, tt.expected, got)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, gotErr, tt.expectedErr)
+			} else if tt.expected {
+				assert.NoError(t, gotErr)
+			} else {
+				assert.Error(t, gotErr)
+			}
+		})
+	}
+
+	t.Run("zip count", func(t *testing.T) {
+		got, gotErr := releaseComplete(filepath.Join("testdata", "Zipped.Release-Group"))
+		assert.NoError(t, gotErr)
+		assert.True(t, got)
+
+		got, gotErr = releaseComplete(filepath.Join("testdata", "Zipped.Missing.File.Release-Group"))
+		assert.Error(t, gotErr)
+		assert.False(t, got)
+	})
+}
@@ -0,0 +1,168 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// inotifyMask holds all events that indicate write activity in a release.
+const inotifyMask = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_MOVED_FROM |
+	unix.IN_DELETE
+
+// watchDir watches the directory and all subdirectories with inotify and sends the name of the
+// release (direct child of dir) on every write activity. The channel is closed after ctx is canceled.
+func watchDir(ctx context.Context, dir string) (<-chan string, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("init inotify: %w", err)
+	}
+
+	w := &inotifyWatcher{
+		fd:      fd,
+		dir:     filepath.Clean(dir),
+		watches: make(map[int]string),
+		changes: make(chan string, 64),
+	}
+
+	if err := w.addRecursive(w.dir, nil); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	go w.run(ctx)
+
+	return w.changes, nil
+}
+
+// inotifyWatcher holds the inotify instance and all watched directories.
+type inotifyWatcher struct {
+	fd      int
+	dir     string
+	watches map[int]string
+	changes chan string
+}
+
+// addRecursive adds a watch for the directory and all its subdirectories.
+// The names of the releases with existing files are added to found, which can be nil.
+func (w *inotifyWatcher) addRecursive(dir string, found map[string]struct{}) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// the directory could have been removed in the meantime
+			if errors.Is(err, fs.ErrNotExist) && path != w.dir {
+				return nil
+			}
+			return err
+		}
+
+		if found != nil && path != w.dir {
+			found[w.releaseName(path)] = struct{}{}
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		wd, err := unix.InotifyAddWatch(w.fd, path, inotifyMask)
+		if err != nil {
+			return fmt.Errorf("add watch %s: %w", path, err)
+		}
+
+		w.watches[wd] = path
+
+		return nil
+	})
+}
+
+// releaseName returns the name of the release (first path element below dir) of the given path.
+func (w *inotifyWatcher) releaseName(path string) string {
+	relPath, err := filepath.Rel(w.dir, path)
+	if err != nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(relPath, string(filepath.Separator))
+	return name
+}
+
+// run reads the inotify events until ctx is canceled.
+func (w *inotifyWatcher) run(ctx context.Context) {
+	defer close(w.changes)
+	defer unix.Close(w.fd)
+
+	const pollTimeout = 200 // milliseconds
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	pollFds := []unix.PollFd{{Fd: int32(w.fd), Events: unix.POLLIN}}
+
+	for ctx.Err() == nil {
+		n, err := unix.Poll(pollFds, pollTimeout)
+		if err != nil && !errors.Is(err, unix.EINTR) {
+			return
+		}
+		if n <= 0 {
+			continue
+		}
+
+		n, err = unix.Read(w.fd, buf)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return
+		}
+
+		changed := make(map[string]struct{})
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(event.Len)]
+			offset += unix.SizeofInotifyEvent + int(event.Len)
+
+			w.handleEvent(event, strings.TrimRight(string(nameBytes), "\x00"), changed)
+		}
+
+		for name := range changed {
+			select {
+			case w.changes <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// handleEvent adds the release of a single event to changed and watches new directories.
+func (w *inotifyWatcher) handleEvent(event *unix.InotifyEvent, name string, changed map[string]struct{}) {
+	// events were lost, so all releases are marked as changed and the watches of new directories are added
+	if event.Mask&unix.IN_Q_OVERFLOW != 0 {
+		_ = w.addRecursive(w.dir, changed)
+		return
+	}
+
+	if event.Mask&unix.IN_IGNORED != 0 {
+		delete(w.watches, int(event.Wd))
+		return
+	}
+
+	dir, ok := w.watches[int(event.Wd)]
+	if !ok || name == "" {
+		return
+	}
+
+	path := filepath.Join(dir, name)
+
+	if releaseName := w.releaseName(path); releaseName != "" {
+		changed[releaseName] = struct{}{}
+	}
+
+	// files can be created in a new directory before its watch is added, so they are collected here
+	if event.Mask&unix.IN_ISDIR != 0 && event.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+		_ = w.addRecursive(path, changed)
+	}
+}
@@ -0,0 +1,105 @@
+//go:build !linux
+
+package release
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// watchPollInterval is the interval in which the directory is scanned for changes.
+const watchPollInterval = time.Second
+
+// watchDir polls the directory and sends the name of the release (direct child of dir) on every
+// change of the size or modification time of its files. The channel is closed after ctx is canceled.
+func watchDir(ctx context.Context, dir string) (<-chan string, error) {
+	snapshot, err := scanReleases(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan string, 64)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := scanReleases(dir)
+			if err != nil {
+				continue
+			}
+
+			for name, state := range current {
+				if snapshot[name] == state {
+					continue
+				}
+
+				select {
+				case changes <- name:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			snapshot = current
+		}
+	}()
+
+	return changes, nil
+}
+
+// releaseState is the summary of all files of a release used to detect changes.
+type releaseState struct {
+	files   int
+	size    int64
+	modTime time.Time
+}
+
+// scanReleases creates the state of every release in the directory.
+func scanReleases(dir string) (map[string]releaseState, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]releaseState, len(entries))
+
+	for _, entry := range entries {
+		var state releaseState
+
+		_ = filepath.WalkDir(filepath.Join(dir, entry.Name()), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+
+			state.files++
+			state.size += info.Size()
+			if info.ModTime().After(state.modTime) {
+				state.modTime = info.ModTime()
+			}
+
+			return nil
+		})
+
+		states[entry.Name()] = state
+	}
+
+	return states, nil
+}
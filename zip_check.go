@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -51,26 +53,60 @@ type archiveCount struct {
 	total   int
 }
 
+// CheckZip checks the zip files of every folder against the file count in their .diz and the sizes of the archives.
+// All folders are checked, on failure a *ReportError is returned that matches ErrZipValidationFailed and the
+// errors of all findings. If extractNFO is set, the first NFO found inside the zip files is stored in the release.
 func (s *Service) CheckZip(rel *Info, extractNFO bool) error {
+	report, err := s.CheckZipReport(rel, extractNFO)
+	if err != nil {
+		return err
+	}
+
+	return report.err(ErrZipValidationFailed)
+}
+
+// CheckZipReport works like CheckZip, but returns the invalid zip files and count or size mismatches as report.
+// An error is only returned if the zip files can't be read at all, e.g. for a release parsed by ParseListing.
+func (s *Service) CheckZipReport(rel *Info, extractNFO bool) (*Report, error) {
 	var nfoFile NFOFile
 
+	report := &Report{}
 	zipFilesByDir := make(map[string][]string)
 
 	for _, file := range rel.Root.GetFiles(".zip") {
-		dirName := file.Parent.Info.Name
-		zipFilesByDir[dirName] = append(zipFilesByDir[dirName], file.FullPath)
+		dir := file.Parent.FullPath
+		zipFilesByDir[dir] = append(zipFilesByDir[dir], file.FullPath)
 	}
 
-	for dir, files := range zipFilesByDir {
+	for _, dir := range slices.Sorted(maps.Keys(zipFilesByDir)) {
 		s.log.Info().Str("folder", dir).Msg("checking zip files")
 
-		result, err := processZipFiles(files)
-		if err != nil {
-			return err
+		findings := len(report.Findings)
+
+		result, err := processZipFiles(rel.files, zipFilesByDir[dir])
+		if errors.Is(err, ErrNoContent) {
+			return nil, err
+		} else if err != nil {
+			s.log.Error().Err(err).Str("folder", dir).Msg("zip check failed")
+			report.add(CodeInvalidZip, SeverityError, dir, err)
+			continue
+		}
+
+		if len(nfoFile.Content) == 0 {
+			nfoFile = result.nfoFile
 		}
 
-		if err := validateArchiveCollection(result); err != nil {
-			return err
+		if err := validateArchiveCount(result); err != nil {
+			report.add(CodeZipCountMismatch, SeverityError, dir, err)
+		}
+
+		if err := validateArchiveSizes(result); err != nil {
+			report.add(CodeZipSizeMismatch, SeverityError, dir, err)
+		}
+
+		if len(report.Findings) > findings {
+			s.log.Error().Str("folder", dir).Msg("zip check failed")
+			continue
 		}
 
 		s.log.Info().Str("folder", dir).Msg("zip check complete")
@@ -80,11 +116,11 @@ func (s *Service) CheckZip(rel *Info, extractNFO bool) error {
 		rel.NFO = &nfoFile
 	}
 
-	return nil
+	return report, nil
 }
 
 // processZipFiles processes a list of zip file paths to extract archive metadata and locate a valid NFO file.
-func processZipFiles(files []string) (archiveResult, error) {
+func processZipFiles(releaseFiles releaseFS, files []string) (archiveResult, error) {
 	var (
 		nfoFile            NFOFile
 		archives           []archiveInfo
@@ -92,15 +128,15 @@ func processZipFiles(files []string) (archiveResult, error) {
 	)
 
 	for _, file := range files {
-		zipReader, err := zip.OpenReader(file)
+		zipReader, closeZip, err := releaseFiles.openZip(file)
 		if err != nil {
 			return archiveResult{}, fmt.Errorf("read zip file: %w", err)
 		}
-		// we close zipReader in processZipContents
 
 		extractNFO := len(nfoFile.Content) == 0
 
 		archiveInfo, nfo, err := processZipContents(zipReader, extractNFO)
+		_ = closeZip()
 		if err != nil {
 			return archiveResult{}, err
 		}
@@ -126,15 +162,13 @@ func processZipFiles(files []string) (archiveResult, error) {
 }
 
 // processZipContents extracts archive and metadata information from a zip file, including NFO content and file count.
-func processZipContents(zipReader *zip.ReadCloser, extractNFO bool) (archiveInfo, NFOFile, error) {
+func processZipContents(zipReader *zip.Reader, extractNFO bool) (archiveInfo, NFOFile, error) {
 	var (
 		archiveCount archiveCount
 		archive      archiveInfo
 		nfoFile      NFOFile
 	)
 
-	defer zipReader.Close()
-
 	for _, zipEntry := range zipReader.File {
 		ext := strings.ToLower(filepath.Ext(zipEntry.Name))
 
@@ -221,13 +255,18 @@ func processDizContent(content []byte) (archiveCount, error) {
 	return archiveCount{current: current, total: total}, nil
 }
 
-// validateArchiveCollection validates if the archive collection matches the expected totals and size constraints.
-func validateArchiveCollection(result archiveResult) error {
+// validateArchiveCount validates if the count of archives matches the expected total.
+func validateArchiveCount(result archiveResult) error {
 	if len(result.archives) != result.expectedTotal {
 		return fmt.Errorf("%w: expected %d archives, got %d",
 			ErrZipValidationFailed, result.expectedTotal, len(result.archives))
 	}
 
+	return nil
+}
+
+// validateArchiveSizes validates if the archives have at most two different sizes (all volumes and the last one).
+func validateArchiveSizes(result archiveResult) error {
 	uniqueSizes := make(map[uint64]struct{})
 	for _, content := range result.archives {
 		uniqueSizes[content.size] = struct{}{}
@@ -1,6 +1,7 @@
 package release_test
 
 import (
+	"os"
 	"testing"
 
 	"github.com/f4n4t/go-release"
@@ -21,7 +22,8 @@ func TestRelease_CheckZip(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			releaseService := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			releaseService, err := release.NewServiceBuilder().WithSkipPre(true).WithSkipMediaInfo(true).Build()
+			require.NoError(t, err)
 			rel, err := releaseService.Parse(tt.folder)
 			require.NoError(t, err)
 
@@ -33,5 +35,20 @@ func TestRelease_CheckZip(t *testing.T) {
 
 			assert.NoError(t, gotErr)
 		})
+
+		t.Run(tt.name+" from fs", func(t *testing.T) {
+			releaseService, err := release.NewServiceBuilder().WithSkipPre(true).Build()
+			require.NoError(t, err)
+			rel, err := releaseService.ParseFS(os.DirFS("."), tt.folder)
+			require.NoError(t, err)
+
+			gotErr := releaseService.CheckZip(rel, false)
+			if tt.wantErr {
+				assert.Error(t, gotErr)
+				return
+			}
+
+			assert.NoError(t, gotErr)
+		})
 	}
 }
@@ -63,11 +63,12 @@ func TestProcessDizFile(t *testing.T) {
 	}
 }
 
-func TestValidateArchiveCollection(t *testing.T) {
+func TestValidateArchives(t *testing.T) {
 	tests := []struct {
-		name          string
-		input         archiveResult
-		expectedError error
+		name     string
+		input    archiveResult
+		countErr error
+		sizesErr error
 	}{
 		{
 			name: "valid single file",
@@ -78,7 +79,6 @@ func TestValidateArchiveCollection(t *testing.T) {
 				},
 				expectedTotal: 2,
 			},
-			expectedError: nil,
 		},
 		{
 			name: "valid two sizes",
@@ -89,7 +89,6 @@ func TestValidateArchiveCollection(t *testing.T) {
 				},
 				expectedTotal: 2,
 			},
-			expectedError: nil,
 		},
 		{
 			name: "invalid expected count",
@@ -99,7 +98,7 @@ func TestValidateArchiveCollection(t *testing.T) {
 				},
 				expectedTotal: 2,
 			},
-			expectedError: ErrZipValidationFailed,
+			countErr: ErrZipValidationFailed,
 		},
 		{
 			name: "invalid sizes",
@@ -111,7 +110,20 @@ func TestValidateArchiveCollection(t *testing.T) {
 				},
 				expectedTotal: 3,
 			},
-			expectedError: ErrZipValidationFailed,
+			sizesErr: ErrZipValidationFailed,
+		},
+		{
+			name: "invalid count and sizes",
+			input: archiveResult{
+				archives: []archiveInfo{
+					{size: 100},
+					{size: 200},
+					{size: 300},
+				},
+				expectedTotal: 4,
+			},
+			countErr: ErrZipValidationFailed,
+			sizesErr: ErrZipValidationFailed,
 		},
 		{
 			name: "empty archives",
@@ -119,7 +131,6 @@ func TestValidateArchiveCollection(t *testing.T) {
 				archives:      []archiveInfo{},
 				expectedTotal: 0,
 			},
-			expectedError: nil,
 		},
 		{
 			name: "invalid no archives with count",
@@ -127,19 +138,24 @@ func TestValidateArchiveCollection(t *testing.T) {
 				archives:      []archiveInfo{},
 				expectedTotal: 1,
 			},
-			expectedError: ErrZipValidationFailed,
+			countErr: ErrZipValidationFailed,
 		},
 	}
 
+	// CheckZipReport adds a finding for each of both checks
+	assertErr := func(t *testing.T, expected, err error) {
+		t.Helper()
+		if expected != nil {
+			assert.ErrorIs(t, err, expected)
+			return
+		}
+		assert.NoError(t, err)
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateArchiveCollection(tt.input)
-			if tt.expectedError != nil {
-				assert.ErrorIs(t, err, tt.expectedError)
-				return
-			}
-
-			assert.NoError(t, err)
+			assertErr(t, tt.countErr, validateArchiveCount(tt.input))
+			assertErr(t, tt.sizesErr, validateArchiveSizes(tt.input))
 		})
 	}
 }
@@ -250,8 +266,9 @@ func TestProcessZipContents(t *testing.T) {
 
 			zipReader, err := zip.OpenReader(tempZipFile)
 			require.NoError(t, err, "error opening test zip file")
+			defer zipReader.Close()
 
-			gotArchive, gotNFO, err := processZipContents(zipReader, tt.extractNFO)
+			gotArchive, gotNFO, err := processZipContents(&zipReader.Reader, tt.extractNFO)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 				return
@@ -374,7 +391,7 @@ func TestProcessZipFiles(t *testing.T) {
 				testFiles = append(testFiles, filepath.Join(tempDir, k))
 			}
 
-			gotResult, err := processZipFiles(testFiles)
+			gotResult, err := processZipFiles(releaseFS{}, testFiles)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 				return